@@ -0,0 +1,524 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+
+	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
+	"github.com/fatih/color"
+)
+
+// battleMove is a move a battler can use, with the PP it has left this battle.
+type battleMove struct {
+	details moveDetails
+	ppLeft  int
+}
+
+// battler is one side of a battle: an individual Pokémon, its species data and its moves.
+type battler struct {
+	pokemon *ownedPokemon
+	details pokemonDetails
+	moves   []*battleMove
+}
+
+// battleState is an ongoing battle between the trainer's lead Pokémon and a wild Pokémon.
+type battleState struct {
+	wild    *battler
+	species pokemonSpecies // The wild Pokémon's species, for its capture rate
+	lead    *battler       // The party Pokémon currently fighting
+}
+
+// battleRNG is the random source for accuracy, critical hits and damage rolls.
+var battleRNG = rand.New(rand.NewSource(rand.Int63()))
+
+// defaultMoveset picks up to four moves the Pokémon could know at its level in a version group
+// (any version group when versionGroup is ""): the most recently learned level-up moves,
+// in the order they were learned.
+func defaultMoveset(details pokemonDetails, level int, versionGroup string) []string {
+	learnedAt := make(map[string]int)
+	for _, move := range details.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			if detail.MoveLearnMethod.Name != "level-up" || detail.LevelLearnedAt > level {
+				continue
+			}
+			if versionGroup != "" && detail.VersionGroup.Name != versionGroup {
+				continue
+			}
+			if known, ok := learnedAt[move.Move.Name]; !ok || detail.LevelLearnedAt < known {
+				learnedAt[move.Move.Name] = detail.LevelLearnedAt
+			}
+		}
+	}
+
+	names := make([]string, 0, len(learnedAt))
+	for name := range learnedAt {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if learnedAt[names[i]] != learnedAt[names[j]] {
+			return learnedAt[names[i]] < learnedAt[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	if len(names) > 4 {
+		names = names[len(names)-4:]
+	}
+	return names
+}
+
+// loadBattler fetches the move data an owned Pokémon needs to battle.
+// Pokémon from older saves without a moveset get their default one.
+func loadBattler(cachePtr *internal.Cache, owned *ownedPokemon, details pokemonDetails) (*battler, error) {
+	b := &battler{pokemon: owned, details: details}
+	if len(owned.Moves) == 0 {
+		owned.Moves = defaultMoveset(details, owned.Level, learnVersionGroup(details, ""))
+	}
+	for _, name := range owned.Moves {
+		move, err := fetchMove(cachePtr, name)
+		if err != nil {
+			return nil, err
+		}
+		b.moves = append(b.moves, &battleMove{details: move, ppLeft: move.PP})
+	}
+	return b, nil
+}
+
+// hasType reports whether a Pokémon has the given type.
+func hasType(details pokemonDetails, typeName string) bool {
+	for _, t := range details.Types {
+		if t.Type.Name == typeName {
+			return true
+		}
+	}
+	return false
+}
+
+// typeEffectiveness returns the damage multiplier of an attacking type against a Pokémon,
+// multiplying the relation to each of the defender's types (e.g. 4x, 0.5x or 0x).
+func typeEffectiveness(cachePtr *internal.Cache, attackType string, defender pokemonDetails) (float64, error) {
+	attacking, err := fetchType(cachePtr, attackType)
+	if err != nil {
+		return 0, err
+	}
+
+	defendingTypes := []string{}
+	for _, t := range defender.Types {
+		defendingTypes = append(defendingTypes, t.Type.Name)
+	}
+	return typeMultiplier(attacking, defendingTypes), nil
+}
+
+// typeMultiplier returns the damage multiplier of an attacking type against defending types.
+func typeMultiplier(attacking typeDetails, defendingTypes []string) float64 {
+	multiplier := 1.0
+	for _, defending := range defendingTypes {
+		for _, relation := range attacking.DamageRelations.DoubleDamageTo {
+			if relation.Name == defending {
+				multiplier *= 2
+			}
+		}
+		for _, relation := range attacking.DamageRelations.HalfDamageTo {
+			if relation.Name == defending {
+				multiplier *= 0.5
+			}
+		}
+		for _, relation := range attacking.DamageRelations.NoDamageTo {
+			if relation.Name == defending {
+				multiplier = 0
+			}
+		}
+	}
+	return multiplier
+}
+
+// useMove lets attacker use a move on defender and prints what happened.
+func useMove(cachePtr *internal.Cache, attacker, defender *battler, move *battleMove) error {
+	move.ppLeft--
+	color.New(color.Bold).Printf("%v used %v!\n", attacker.pokemon.displayName(), move.details.Name)
+
+	if move.details.Accuracy != nil && battleRNG.Intn(100) >= *move.details.Accuracy {
+		color.New(color.FgHiBlack).Println("  But it missed!")
+		return nil
+	}
+	if move.details.Power == nil || move.details.DamageClass.Name == "status" {
+		color.New(color.FgHiBlack).Println("  (Status effects aren't simulated... nothing happened.)")
+		return nil
+	}
+
+	effectiveness, err := typeEffectiveness(cachePtr, move.details.Type.Name, defender.details)
+	if err != nil {
+		return err
+	}
+
+	// Physical moves use Attack vs Defense, special moves Sp. Atk vs Sp. Def.
+	attackStat, defenseStat := "attack", "defense"
+	if move.details.DamageClass.Name == "special" {
+		attackStat, defenseStat = "special-attack", "special-defense"
+	}
+	crit := battleRNG.Float64() < internal.CritChance
+	damage := internal.Damage(
+		attacker.pokemon.Level,
+		*move.details.Power,
+		attacker.pokemon.actualStats(attacker.details)[attackStat],
+		defender.pokemon.actualStats(defender.details)[defenseStat],
+		hasType(attacker.details, move.details.Type.Name),
+		effectiveness,
+		crit,
+		0.85+battleRNG.Float64()*0.15,
+	)
+
+	hp := defender.pokemon.currentHP(defender.details)
+	if damage > hp {
+		damage = hp
+	}
+	defender.pokemon.Damage += damage
+
+	switch {
+	case effectiveness == 0:
+		color.New(color.FgHiBlack).Printf("  It doesn't affect %v...\n", defender.pokemon.displayName())
+		return nil
+	case effectiveness > 1:
+		color.New(color.FgHiGreen, color.Bold).Println("  It's super effective!")
+	case effectiveness < 1:
+		color.New(color.FgYellow).Println("  It's not very effective...")
+	}
+	if crit {
+		color.New(color.FgHiYellow, color.Bold).Println("  A critical hit!")
+	}
+	color.New(color.FgWhite).Printf("  %v took %d damage.\n", defender.pokemon.displayName(), damage)
+	return nil
+}
+
+// pickWildMove chooses a random move with PP left, falling back to struggle.
+func pickWildMove(cachePtr *internal.Cache, wild *battler) (*battleMove, error) {
+	usable := []*battleMove{}
+	for _, move := range wild.moves {
+		if move.ppLeft > 0 {
+			usable = append(usable, move)
+		}
+	}
+	if len(usable) == 0 {
+		return struggle(cachePtr)
+	}
+	return usable[battleRNG.Intn(len(usable))], nil
+}
+
+// struggle is the move used when a Pokémon has no PP left.
+func struggle(cachePtr *internal.Cache) (*battleMove, error) {
+	move, err := fetchMove(cachePtr, "struggle")
+	if err != nil {
+		return nil, err
+	}
+	return &battleMove{details: move, ppLeft: 1}, nil
+}
+
+// printBattleStatus shows both Pokémon's HP and the lead's moves.
+func printBattleStatus(battle *battleState) {
+	wild, lead := battle.wild, battle.lead
+	color.New(color.FgHiMagenta, color.Bold).Printf("Wild %v Lv. %d  HP %d/%d\n",
+		wild.pokemon.Species, wild.pokemon.Level,
+		wild.pokemon.currentHP(wild.details), wild.pokemon.actualStats(wild.details)["hp"])
+	color.New(color.FgHiYellow, color.Bold).Printf("Your %v Lv. %d  HP %d/%d\n",
+		lead.pokemon.displayName(), lead.pokemon.Level,
+		lead.pokemon.currentHP(lead.details), lead.pokemon.actualStats(lead.details)["hp"])
+
+	color.New(color.FgCyan, color.Bold).Println("Moves (fight <move|number>):")
+	for i, move := range lead.moves {
+		power := "-"
+		if move.details.Power != nil {
+			power = strconv.Itoa(*move.details.Power)
+		}
+		color.New(color.Bold).Printf("  %d. %v", i+1, move.details.Name)
+		color.New(color.FgHiBlack).Printf("  [%v, %v, power %v, PP %d/%d]\n",
+			move.details.Type.Name, move.details.DamageClass.Name, power, move.ppLeft, move.details.PP)
+	}
+	color.New(color.FgHiBlack).Println("Or: catch, run")
+}
+
+// nextHealthy returns the first party Pokémon that hasn't fainted.
+func nextHealthy(configPtr *config, pokedex map[string]pokemonDetails) *ownedPokemon {
+	for _, owned := range configPtr.Party {
+		if owned.currentHP(pokedex[owned.Species]) > 0 {
+			return owned
+		}
+	}
+	return nil
+}
+
+// healParty restores every party Pokémon to full health.
+func healParty(configPtr *config) {
+	for _, owned := range configPtr.Party {
+		owned.Damage = 0
+	}
+	configPtr.unsaved = true
+}
+
+// endBattle clears the battle and the wild encounter it was fought against.
+func endBattle(configPtr *config) {
+	configPtr.Battle = nil
+	configPtr.Encounter = nil
+	configPtr.unsaved = true
+}
+
+// wildTurn lets the wild Pokémon attack, then handles the lead fainting:
+// the next healthy party member is sent out, or the trainer blacks out.
+func wildTurn(configPtr *config, cachePtr *internal.Cache, pokedex map[string]pokemonDetails) error {
+	battle := configPtr.Battle
+	move, err := pickWildMove(cachePtr, battle.wild)
+	if err != nil {
+		return err
+	}
+	if err := useMove(cachePtr, battle.wild, battle.lead, move); err != nil {
+		return err
+	}
+	return checkLeadFainted(configPtr, cachePtr, pokedex)
+}
+
+// checkLeadFainted switches in the next healthy party member if the lead has fainted.
+func checkLeadFainted(configPtr *config, cachePtr *internal.Cache, pokedex map[string]pokemonDetails) error {
+	battle := configPtr.Battle
+	if battle.lead.pokemon.currentHP(battle.lead.details) > 0 {
+		return nil
+	}
+	color.New(color.FgHiRed, color.Bold).Printf("%v fainted!\n", battle.lead.pokemon.displayName())
+
+	next := nextHealthy(configPtr, pokedex)
+	if next == nil {
+		color.New(color.FgHiRed, color.Bold).Println("You have no Pokémon left that can fight... You blacked out!")
+		color.New(color.FgCyan).Println("You hurry back to a Pokémon Center, where your party is healed.")
+		healParty(configPtr)
+		endBattle(configPtr)
+		return nil
+	}
+
+	lead, err := loadBattler(cachePtr, next, pokedex[next.Species])
+	if err != nil {
+		return err
+	}
+	battle.lead = lead
+	configPtr.unsaved = true
+	color.New(color.FgHiYellow, color.Bold).Printf("Go, %v!\n", next.displayName())
+	return nil
+}
+
+// wildFainted ends the battle with a win once the wild Pokémon has no HP left,
+// awarding experience to the lead.
+func wildFainted(configPtr *config, cachePtr *internal.Cache) (bool, error) {
+	battle := configPtr.Battle
+	wild := battle.wild
+	if wild.pokemon.currentHP(wild.details) > 0 {
+		return false, nil
+	}
+	color.New(color.FgHiGreen, color.Bold).Printf("The wild %v fainted! You won the battle.\n", wild.pokemon.Species)
+	endBattle(configPtr)
+	return true, gainExperience(configPtr, cachePtr, battle.lead.pokemon, battle.lead.details, wild)
+}
+
+// battle starts a battle between the party lead and a wild Pokémon of the current area:
+// the one met by walk/surf/fish, or a new one found by walking. During a battle it shows the status.
+func battle(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	if configPtr.Battle != nil {
+		printBattleStatus(configPtr.Battle)
+		return nil
+	}
+
+	lead := nextHealthy(configPtr, pokedex)
+	if lead == nil {
+		color.New(color.FgHiRed, color.Bold).Println("You have no Pokémon that can fight! Catch one, or heal your party.")
+		return nil
+	}
+	if configPtr.Encounter == nil {
+		if err := searchForEncounter(configPtr, cachePtr, "walk", args); err != nil {
+			return err
+		}
+		if configPtr.Encounter == nil {
+			return nil
+		}
+	}
+	encounter := configPtr.Encounter
+
+	wildDetails, err := fetchPokemon(cachePtr, encounter.Pokemon)
+	if err != nil {
+		return err
+	}
+	species, err := fetchSpecies(cachePtr, wildDetails.Species.Name)
+	if err != nil {
+		return err
+	}
+	wildPokemon := newOwnedPokemon(0, encounter.Pokemon, encounter.Level, species.GenderRate, encounter.Shiny, configPtr.CurrentArea, encounterRNG)
+	if err := initProgress(cachePtr, wildPokemon, wildDetails, configPtr.VersionGroup); err != nil {
+		return err
+	}
+	wild, err := loadBattler(cachePtr, wildPokemon, wildDetails)
+	if err != nil {
+		return err
+	}
+	leadBattler, err := loadBattler(cachePtr, lead, pokedex[lead.Species])
+	if err != nil {
+		return err
+	}
+
+	configPtr.Battle = &battleState{wild: wild, species: species, lead: leadBattler}
+	if encounter.Shiny {
+		color.New(color.FgHiYellow, color.Bold).Printf("★ The shiny wild %v wants to battle! ★\n", encounter.Pokemon)
+	} else {
+		color.New(color.FgHiMagenta, color.Bold).Printf("The wild %v wants to battle!\n", encounter.Pokemon)
+	}
+	color.New(color.FgHiYellow, color.Bold).Printf("Go, %v!\n", lead.displayName())
+	printBattleStatus(configPtr.Battle)
+	return nil
+}
+
+// fight uses one of the lead's moves, by name or by its number in the move list.
+// The faster Pokémon (or the one using a higher-priority move) attacks first.
+func fight(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	battle := configPtr.Battle
+	if battle == nil {
+		color.New(color.FgHiRed, color.Bold).Println("You're not in a battle. Use battle to start one.")
+		return nil
+	}
+	if len(args) == 0 {
+		color.New(color.FgHiRed, color.Bold).Println("Error: missing move argument.")
+		return nil
+	}
+
+	var move *battleMove
+	for i, candidate := range battle.lead.moves {
+		if candidate.details.Name == args[0] || strconv.Itoa(i+1) == args[0] {
+			move = candidate
+		}
+	}
+	if move == nil {
+		color.New(color.FgHiRed, color.Bold).Printf("%v doesn't know %v.\n", battle.lead.pokemon.displayName(), args[0])
+		return nil
+	}
+	if move.ppLeft <= 0 {
+		color.New(color.FgHiRed, color.Bold).Printf("There's no PP left for %v!\n", move.details.Name)
+		return nil
+	}
+
+	wildMove, err := pickWildMove(cachePtr, battle.wild)
+	if err != nil {
+		return err
+	}
+
+	// Priority first, then speed; ties are decided by a coin flip.
+	leadSpeed := battle.lead.pokemon.actualStats(battle.lead.details)["speed"]
+	wildSpeed := battle.wild.pokemon.actualStats(battle.wild.details)["speed"]
+	leadFirst := leadSpeed > wildSpeed || (leadSpeed == wildSpeed && battleRNG.Intn(2) == 0)
+	if move.details.Priority != wildMove.details.Priority {
+		leadFirst = move.details.Priority > wildMove.details.Priority
+	}
+
+	if leadFirst {
+		if err := useMove(cachePtr, battle.lead, battle.wild, move); err != nil {
+			return err
+		}
+		if won, err := wildFainted(configPtr, cachePtr); won || err != nil {
+			return err
+		}
+		if err := useMove(cachePtr, battle.wild, battle.lead, wildMove); err != nil {
+			return err
+		}
+		if err := checkLeadFainted(configPtr, cachePtr, pokedex); err != nil {
+			return err
+		}
+	} else {
+		if err := useMove(cachePtr, battle.wild, battle.lead, wildMove); err != nil {
+			return err
+		}
+		if battle.lead.pokemon.currentHP(battle.lead.details) > 0 {
+			if err := useMove(cachePtr, battle.lead, battle.wild, move); err != nil {
+				return err
+			}
+			if won, err := wildFainted(configPtr, cachePtr); won || err != nil {
+				return err
+			}
+		}
+		if err := checkLeadFainted(configPtr, cachePtr, pokedex); err != nil {
+			return err
+		}
+	}
+
+	configPtr.unsaved = true // The party took damage
+	if configPtr.Battle != nil {
+		fmt.Println()
+		printBattleStatus(configPtr.Battle)
+	}
+	return nil
+}
+
+// run tries to flee from the battle. A faster lead always gets away.
+func run(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	battle := configPtr.Battle
+	if battle == nil {
+		color.New(color.FgHiRed, color.Bold).Println("You're not in a battle.")
+		return nil
+	}
+
+	leadSpeed := battle.lead.pokemon.actualStats(battle.lead.details)["speed"]
+	wildSpeed := battle.wild.pokemon.actualStats(battle.wild.details)["speed"]
+	if leadSpeed >= wildSpeed || battleRNG.Intn(2) == 0 {
+		color.New(color.FgCyan, color.Bold).Println("Got away safely!")
+		endBattle(configPtr)
+		return nil
+	}
+
+	color.New(color.FgHiRed, color.Bold).Println("Can't escape!")
+	return wildTurn(configPtr, cachePtr, pokedex)
+}
+
+// battleCatch throws a Pokéball during a battle. The lower the wild Pokémon's HP,
+// the better the odds; a failed throw gives the wild Pokémon a free turn.
+func battleCatch(configPtr *config, cachePtr *internal.Cache, pokedex map[string]pokemonDetails) error {
+	battle := configPtr.Battle
+	wild := battle.wild.pokemon
+
+	color.New(color.Bold).Printf("Throwing a Pokéball at the wild %v...\n", wild.Species)
+	chance := internal.CatchChance(wild.actualStats(battle.wild.details)["hp"], wild.currentHP(battle.wild.details), battle.species.CaptureRate)
+	if battleRNG.Float64() >= chance {
+		configPtr.recordThrow(false)
+		color.New(color.FgHiRed, color.Bold).Println("Oh no! The Pokémon broke free!")
+		return wildTurn(configPtr, cachePtr, pokedex)
+	}
+
+	// The wild Pokémon keeps the individual data it battled with.
+	wild.ID = configPtr.NextID + 1
+	where, err := configPtr.storeCaught(wild)
+	if err != nil {
+		return err
+	}
+	configPtr.NextID++
+	pokedex[wild.Species] = battle.wild.details
+	configPtr.markSeen(wild.Species)
+	configPtr.recordThrow(true)
+	endBattle(configPtr)
+
+	color.New(color.FgHiGreen, color.Bold).Printf("Gotcha! %v was caught! (#%d, Lv. %d, sent to %v)\n", wild.Species, wild.ID, wild.Level, where)
+	if wild.Shiny {
+		color.New(color.FgHiYellow, color.Bold).Println("★ It's shiny! ★")
+	}
+	return nil
+}
+
+// busyInBattle prints a reminder and returns true when a battle is in progress,
+// for commands that can't be used until it is over.
+func busyInBattle(configPtr *config) bool {
+	if configPtr.Battle == nil {
+		return false
+	}
+	color.New(color.FgHiRed, color.Bold).Println("You're in the middle of a battle! Fight, catch or run first.")
+	return true
+}
+
+// heal restores the party to full health at a Pokémon Center.
+func heal(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	if busyInBattle(configPtr) {
+		return nil
+	}
+	healParty(configPtr)
+	color.New(color.FgHiGreen, color.Bold).Println("Your Pokémon have been restored to full health. We hope to see you again!")
+	return nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// testPokemonMoves is a trimmed pokemon response with level-up, machine and duplicate moves.
+const testPokemonMoves = `{
+	"name": "pikachu",
+	"moves": [
+		{"move": {"name": "thunder-shock"}, "version_group_details": [
+			{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}}
+		]},
+		{"move": {"name": "growl"}, "version_group_details": [
+			{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}}
+		]},
+		{"move": {"name": "thunder-wave"}, "version_group_details": [
+			{"level_learned_at": 9, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}}
+		]},
+		{"move": {"name": "quick-attack"}, "version_group_details": [
+			{"level_learned_at": 16, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}},
+			{"level_learned_at": 11, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "x-y", "url": "https://pokeapi.co/api/v2/version-group/15/"}}
+		]},
+		{"move": {"name": "swift"}, "version_group_details": [
+			{"level_learned_at": 26, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}}
+		]},
+		{"move": {"name": "thunderbolt"}, "version_group_details": [
+			{"level_learned_at": 0, "move_learn_method": {"name": "machine"}, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}}
+		]}
+	]
+}`
+
+// TestDefaultMoveset checks that the four most recently learned level-up moves
+// of the version group are picked.
+func TestDefaultMoveset(t *testing.T) {
+	var details pokemonDetails
+	if err := json.Unmarshal([]byte(testPokemonMoves), &details); err != nil {
+		t.Fatalf("could not parse test pokemon: %v", err)
+	}
+
+	cases := []struct {
+		level    int
+		expected []string
+	}{
+		{level: 5, expected: []string{"growl", "thunder-shock"}},
+		{level: 12, expected: []string{"growl", "thunder-shock", "thunder-wave"}},
+		{level: 16, expected: []string{"growl", "thunder-shock", "thunder-wave", "quick-attack"}},
+		{level: 30, expected: []string{"thunder-shock", "thunder-wave", "quick-attack", "swift"}},
+	}
+
+	for _, c := range cases {
+		actual := defaultMoveset(details, c.level, "red-blue")
+		if len(actual) != len(c.expected) {
+			t.Errorf("Lv. %v: Expected %v, Got %v", c.level, c.expected, actual)
+			continue
+		}
+		for i := range actual {
+			if actual[i] != c.expected[i] {
+				t.Errorf("Lv. %v: Expected %v, Got %v", c.level, c.expected, actual)
+				break
+			}
+		}
+	}
+}
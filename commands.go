@@ -2,14 +2,17 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt" // Package for formatted I/O (input/output)
 	"io"
-	"log"
 	"net/http"
 	"os" // Package for operating system functionalities, like exiting the program¨
 	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
 	"math/rand"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 	"github.com/fatih/color"
 )
 
@@ -17,22 +20,48 @@ import (
 type cliCommand struct {
 	name        string              // The name of the command (e.g., "help")
 	description string              // A short description of this command
-	callback    func(*config, *internal.Cache, string, map[string]pokemonDetails) error // The function executed when this command is invoked
+	callback    func(*config, *internal.Cache, []string, map[string]pokemonDetails) error // The function executed when this command is invoked
 }
 
 // locations holds the results from the PokeAPI location-area endpoint.
 type locations struct {
+	Count   int `json:"count"` // Number of location areas in all pages
 	Results []struct {
 		Name string `json:"name"` // Name of the location area
 		URL  string `json:"url"`  // API URL for details about this location area
 	} `json:"results"`
 }
 
-// config stores pagination URLs and result count for navigating paginated PokeAPI responses.
+// config carries the session state, such as where the trainer currently is.
 type config struct {
-	Count    int     `json:"count"`
-	Next     string  `json:"next"`     // URL for the next set of results, or "" if none
-	Previous *string `json:"previous"` // URL for the previous set, or nil if on the first page
+	CurrentArea string        `json:"-"` // Location area the trainer is in, or "" before the first travel/explore
+	Encounter   *wildEncounter `json:"-"` // The wild Pokémon last met by walk/surf/fish, or nil
+
+	Party  []*ownedPokemon   `json:"-"` // Up to six Pokémon the trainer carries
+	Boxes  [][]*ownedPokemon `json:"-"` // PC boxes for every other owned Pokémon
+	NextID int               `json:"-"` // ID handed to the next caught Pokémon
+	Seen   map[string]bool   `json:"-"` // Species the trainer has come across in the wild
+	Battle *battleState      `json:"-"` // The ongoing battle, or nil
+
+	Version      string            `json:"-"` // Game being played (e.g. "red"), or "" for all games
+	VersionGroup string            `json:"-"` // Version group whose learnsets apply, set along with Version by the version command; "" for each Pokémon's newest
+	Evolution    *pendingEvolution `json:"-"` // An evolution waiting for yes/no, or nil
+	Team         []*teamMember     `json:"-"` // The team being built with the team command
+	Trainer      trainerProfile    `json:"-"` // Name, play time, statistics and achievements
+	ShinyOdds    int               `json:"-"` // Shiny odds (1 in ShinyOdds), or 0 for defaultShinyOdds
+
+	typed        []string               // The words of the current command as typed, before cleanInput lowercased them
+	unsaved      bool                   // Set by commands that change game state, so the REPL knows to save
+	versionAreas []string               // Location areas of the selected game, built by the first map
+	mapShown     bool                   // Whether map has shown a page yet
+	mapOffset    int                    // Offset of the page map showed last
+	mapLimit     int                    // Areas per map page, or 0 for the default
+	indexPath    string                 // Where the name index is stored, or "" to keep it in memory
+	names        *nameIndex             // Name index used by search and did-you-mean suggestions
+	typeChart    map[string]typeDetails // Damage relations of all types, fetched by the first team analysis
+	nationalDex  []dexEntry             // Every species in national dex order, fetched by the first pokedex --national
+	lastActive   time.Time              // When the previous command ran, for the play time
+	playTimeDue  bool                   // Play time was counted since the last save; it rides along with the next one
 }
 
 type locationAreaDetails struct {
@@ -45,6 +74,26 @@ type locationAreaDetails struct {
 			Name string `json:"name"`
 			URL  string `json:"url"`
 		} `json:"pokemon"`
+		VersionDetails []struct {
+			EncounterDetails []struct {
+				Chance          int `json:"chance"` // Percent chance of this slot for the method
+				ConditionValues []struct {
+					Name string `json:"name"` // e.g. "time-morning", "swarm-no"
+					URL  string `json:"url"`
+				} `json:"condition_values"`
+				MaxLevel int `json:"max_level"`
+				Method   struct {
+					Name string `json:"name"` // e.g. "walk", "surf", "old-rod"
+					URL  string `json:"url"`
+				} `json:"method"`
+				MinLevel int `json:"min_level"`
+			} `json:"encounter_details"`
+			MaxChance int `json:"max_chance"`
+			Version   struct {
+				Name string `json:"name"` // Game version, e.g. "red" or "diamond"
+				URL  string `json:"url"`
+			} `json:"version"`
+		} `json:"version_details"`
 	} `json:"pokemon_encounters"`
 }
 
@@ -330,6 +379,137 @@ type pokemonDetails struct {
 	Weight int `json:"weight"`
 }
 
+// pokemonSpecies holds the species-level data from the PokeAPI pokemon-species endpoint.
+type pokemonSpecies struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	GenderRate  int    `json:"gender_rate"`  // Chance of being female in eighths, or -1 for genderless
+	CaptureRate int    `json:"capture_rate"` // 3 (hardest) to 255 (easiest)
+	GrowthRate  struct {
+		Name string `json:"name"` // Experience curve, e.g. "medium-slow"
+		URL  string `json:"url"`
+	} `json:"growth_rate"`
+	EvolutionChain struct {
+		URL string `json:"url"`
+	} `json:"evolution_chain"`
+	FlavorTextEntries []struct {
+		FlavorText string `json:"flavor_text"`
+		Language   struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"language"`
+		Version struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"version"`
+	} `json:"flavor_text_entries"`
+	Varieties []struct {
+		IsDefault bool `json:"is_default"`
+		Pokemon   struct {
+			Name string `json:"name"` // Pokémon name of this variety, e.g. "vulpix-alola"
+			URL  string `json:"url"`
+		} `json:"pokemon"`
+	} `json:"varieties"`
+}
+
+// defaultPokemon returns the Pokémon name of the species' default variety,
+// which is usually, but not always, the species name.
+func (species pokemonSpecies) defaultPokemon() string {
+	for _, variety := range species.Varieties {
+		if variety.IsDefault {
+			return variety.Pokemon.Name
+		}
+	}
+	return species.Name
+}
+
+// moveDetails holds the battle data of a move from the PokeAPI move endpoint.
+type moveDetails struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Accuracy *int   `json:"accuracy"` // nil for moves that never miss
+	Power    *int   `json:"power"`    // nil for status moves
+	PP       int    `json:"pp"`
+	Priority int    `json:"priority"`
+	Type     struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"type"`
+	DamageClass struct {
+		Name string `json:"name"` // "physical", "special" or "status"
+		URL  string `json:"url"`
+	} `json:"damage_class"`
+	EffectChance  *int          `json:"effect_chance"` // Substituted for $effect_chance in the effect text
+	EffectEntries []effectEntry `json:"effect_entries"`
+}
+
+// effectEntry is a description of a move's or ability's effect in one language.
+type effectEntry struct {
+	Effect      string `json:"effect"`
+	ShortEffect string `json:"short_effect"`
+	Language    struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"language"`
+}
+
+// abilityDetails holds the data from the PokeAPI ability endpoint.
+type abilityDetails struct {
+	ID            int           `json:"id"`
+	Name          string        `json:"name"`
+	EffectEntries []effectEntry `json:"effect_entries"`
+	Generation    struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"generation"`
+	Pokemon []struct {
+		IsHidden bool `json:"is_hidden"`
+		Pokemon  struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"pokemon"`
+	} `json:"pokemon"`
+}
+
+// typeDetails holds the damage relations of a type from the PokeAPI type endpoint.
+type typeDetails struct {
+	ID              int    `json:"id"`
+	Name            string `json:"name"`
+	DamageRelations struct {
+		DoubleDamageFrom []struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"double_damage_from"`
+		DoubleDamageTo []struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"double_damage_to"`
+		HalfDamageFrom []struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"half_damage_from"`
+		HalfDamageTo []struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"half_damage_to"`
+		NoDamageFrom []struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"no_damage_from"`
+		NoDamageTo []struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"no_damage_to"`
+	} `json:"damage_relations"`
+	Pokemon []struct {
+		Pokemon struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"pokemon"`
+		Slot int `json:"slot"`
+	} `json:"pokemon"`
+}
+
 const maxBaseExp = 300 // max base exp for calculating chance to capture pokemon! (mew.base_experience = 270 exp, it was used as a threshold for the max base exp)
 
 
@@ -353,7 +533,7 @@ func init() {
 		},
 		"map": {
 			name:        "map",
-			description: "List the next page of Pokémon location areas you can explore.",
+			description: "List the next page of location areas (--page <n>, --first, --last, --limit <size>, --filter <text>).",
 			callback:    commandMap,
 		},
 		"mapb": {
@@ -361,32 +541,202 @@ func init() {
 			description: "List the previous page of Pokémon location areas.",
 			callback:    commandMapB,
 		},
+		"regions": {
+			name:        "regions",
+			description: "List all regions.",
+			callback:    regions,
+		},
+		"region": {
+			name:        "region",
+			description: "List the locations of a region in order: region <name>.",
+			callback:    region,
+		},
+		"location": {
+			name:        "location",
+			description: "List the explorable areas of a location: location <name>.",
+			callback:    location,
+		},
+		"travel": {
+			name:        "travel",
+			description: "Travel to a location area; you can only catch Pokémon found where you are.",
+			callback:    travel,
+		},
 		"explore": {
 			name:        "explore",
-			description: "Show all Pokémon that can be encountered in a specified location area.",
+			description: "Show all Pokémon that can be encountered in a location area (defaults to where you are) and travel there.",
 			callback:    explore,
 		},
+		"walk": {
+			name:        "walk",
+			description: "Walk through the tall grass of the current area until a wild Pokémon appears (--game <version>).",
+			callback:    walk,
+		},
+		"surf": {
+			name:        "surf",
+			description: "Surf the waters of the current area until a wild Pokémon appears (--game <version>).",
+			callback:    surf,
+		},
+		"fish": {
+			name:        "fish",
+			description: "Fish in the current area with a rod: fish <old|good|super> (--game <version>).",
+			callback:    fish,
+		},
 		"catch": {
 			name:        "catch",
-			description: "Attempt to catch a Pokémon by name and add it to your Pokedex if successful.",
+			description: "Attempt to catch a Pokémon found in the current area (--form <form> for a variety such as alola, --cheat to catch anything); in a battle, throw at the wild Pokémon with HP-based odds.",
 			callback:    catch,
 		},
+		"battle": {
+			name:        "battle",
+			description: "Battle the wild Pokémon you met (or a new one from walking) with your party lead; shows the status mid-battle.",
+			callback:    battle,
+		},
+		"fight": {
+			name:        "fight",
+			description: "In a battle, use one of your Pokémon's moves: fight <move|number>.",
+			callback:    fight,
+		},
+		"run": {
+			name:        "run",
+			description: "Try to flee from a battle.",
+			callback:    run,
+		},
+		"moves": {
+			name:        "moves",
+			description: "Show an owned Pokémon's moveset and the moves it could learn: moves <pokemon|#ID>.",
+			callback:    moves,
+		},
+		"teach": {
+			name:        "teach",
+			description: "Teach an owned Pokémon a move it can learn by level-up: teach <pokemon> <move> [move to forget].",
+			callback:    teach,
+		},
+		"move": {
+			name:        "move",
+			description: "Look up a move: effect, power, accuracy, PP, type, and which of your Pokémon can learn it: move <name>.",
+			callback:    move,
+		},
+		"ability": {
+			name:        "ability",
+			description: "Look up an ability: its effect and which of your Pokémon have it: ability <name>.",
+			callback:    ability,
+		},
+		"forms": {
+			name:        "forms",
+			description: "List the forms and regional variants of a species, and which ones you have caught: forms <species>.",
+			callback:    forms,
+		},
+		"sprite": {
+			name:        "sprite",
+			description: "Draw a Pokémon's sprite in the terminal: sprite <pokemon|#id|nickname> [--shiny] [--back] [--game red-blue].",
+			callback:    sprite,
+		},
+		"compare": {
+			name:        "compare",
+			description: "Compare the stats, types and abilities of two or more Pokémon side by side (names, #IDs or nicknames).",
+			callback:    compare,
+		},
+		"team": {
+			name:        "team",
+			description: "Build a team and see its type weaknesses and coverage (team add <pokemon|#id> [moves...], team moves <slot> <moves...>, team remove <slot>, team party, team clear, team export [file], team import <file>).",
+			callback:    team,
+		},
+		"export": {
+			name:        "export",
+			description: "Export your caught Pokémon to a file (export csv|json|markdown|html <path>).",
+			callback:    export,
+		},
+		"search": {
+			name:        "search",
+			description: "Find Pokémon, moves, abilities, items and locations by approximate name (--kind <kind>, --limit <n>, --refresh).",
+			callback:    search,
+		},
+		"version": {
+			name:        "version",
+			description: "Choose the game you're playing (e.g. version red), or version none for all games; shows the current one without arguments.",
+			callback:    version,
+		},
+		"learnset": {
+			name:        "learnset",
+			description: "Show the moves a Pokémon learns in a game: learnset <pokemon> [--game sword|sword-shield] [--method level-up|machine|egg|tutor].",
+			callback:    learnsetCmd,
+		},
+		"use": {
+			name:        "use",
+			description: "Use an item on an owned Pokémon, e.g. an evolution stone: use <item> on <pokemon>.",
+			callback:    use,
+		},
+		"heal": {
+			name:        "heal",
+			description: "Visit a Pokémon Center and restore your party to full health.",
+			callback:    heal,
+		},
 		"inspect": {
 			name:        "inspect",
-			description: "View detailed stats and information about a Pokémon you have caught.",
+			description: "View details about a caught Pokémon by species, or an individual by #ID or nickname (--moves [--version-group <name>], --abilities, --no-sprite).",
 			callback:    inspect,
 		},
+		"shiny": {
+			name:        "shiny",
+			description: "Show the shiny odds and your shiny Pokémon, or change the odds: shiny <n> for 1 in n, shiny default.",
+			callback:    commandShiny,
+		},
+		"trainer": {
+			name:        "trainer",
+			description: "Show your trainer profile: play time, Pokéballs thrown and areas explored (trainer name <name> to set your name).",
+			callback:    trainer,
+		},
+		"achievements": {
+			name:        "achievements",
+			description: "List the achievements, with the ones you have unlocked and your progress toward the rest.",
+			callback:    showAchievements,
+		},
 		"pokedex": {
 			name:        "pokedex",
-			description: "Display a list of all Pokémon you have successfully caught.",
+			description: "Display every Pokémon you have seen and caught, and how many you still own (--sort id|name|type|bst|caught-at, --type <type>, --gen <n|region>, --shiny, --limit <n>, --page <n>, --national, --missing, --completion).",
 			callback:    pokedex,
 		},
+		"party": {
+			name:        "party",
+			description: "List the (up to six) Pokémon in your party.",
+			callback:    party,
+		},
+		"box": {
+			name:        "box",
+			description: "Show the contents of a PC box: box [n].",
+			callback:    box,
+		},
+		"deposit": {
+			name:        "deposit",
+			description: "Move a party Pokémon into the PC: deposit <pokemon|#ID>.",
+			callback:    deposit,
+		},
+		"withdraw": {
+			name:        "withdraw",
+			description: "Move a Pokémon from the PC into your party: withdraw <pokemon|#ID>.",
+			callback:    withdraw,
+		},
+		"swap": {
+			name:        "swap",
+			description: "Swap the places of two owned Pokémon (party order or party <-> PC): swap <a> <b>.",
+			callback:    swap,
+		},
+		"release": {
+			name:        "release",
+			description: "Release an owned Pokémon for good: release <pokemon|#ID>.",
+			callback:    release,
+		},
+		"nickname": {
+			name:        "nickname",
+			description: "Give an owned Pokémon a nickname (or clear it): nickname <pokemon|#ID> [name].",
+			callback:    nickname,
+		},
 	}
 }
 
 // commandExit terminates the CLI Pokedex application immediately.
 // It now prints the goodbye message in yellow for extra flair!
-func commandExit(configPtr *config, cachePtr *internal.Cache, location string, pokedex map[string]pokemonDetails) error {
+func commandExit(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
     // Bright yellow bold goodbye for a positive, friendly signoff
     color.New(color.FgHiYellow, color.Bold).Println("Closing the Pokedex... Goodbye!")
     os.Exit(0)
@@ -395,7 +745,7 @@ func commandExit(configPtr *config, cachePtr *internal.Cache, location string, p
 
 // commandHelp prints information about all available CLI commands.
 // It lists each command with its name and description.
-func commandHelp(configPtr *config, cachePtr *internal.Cache, location string, pokedex map[string]pokemonDetails) error {
+func commandHelp(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
     color.New(color.FgCyan, color.Bold).Println("Welcome to the Pokedex!")
     fmt.Println("Usage:")
 	fmt.Println()
@@ -409,224 +759,386 @@ func commandHelp(configPtr *config, cachePtr *internal.Cache, location string, p
 
 // commandMap fetches and displays a paginated list of location areas from the PokeAPI.
 // It uses a cache to avoid unnecessary HTTP requests for previously seen pages.
-func commandMap(configPtr *config, cachePtr *internal.Cache, location string, pokedex map[string]pokemonDetails) error {
-    var url string
+// Flags jump around the list: --page <n>, --first, --last, --limit <size>,
+// and --filter <text> searches the names on every page.
+func commandMap(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+    _, flags := parseFlags(args, "first", "last")
 
-    // Determine which URL to fetch: next page or default start page
-    if configPtr.Next == "" {
-        url = "https://pokeapi.co/api/v2/location-area/?offset=0&limit=20"
-    } else {
-        url = configPtr.Next
+    if value, ok := flags["limit"]; ok {
+        limit, err := strconv.Atoi(value)
+        if err != nil || limit < 1 || limit > maxMapLimit {
+            color.New(color.FgHiRed, color.Bold).Printf("The limit must be a number from 1 to %d.\n", maxMapLimit)
+            return nil
+        }
+        configPtr.mapLimit = limit
     }
+    limit := configPtr.pageLimit()
 
-    // Try to get the response data from the cache first.
-    val, ok := cachePtr.Get(url)
-    if !ok {
-        // Not in cache! Make HTTP request to fetch data from the API
-        res, err := http.Get(url)
-        if err != nil {
-            return err
+    if filter, ok := flags["filter"]; ok {
+        if filter == "" {
+            color.New(color.FgHiRed, color.Bold).Println("Usage: map --filter <text>")
+            return nil
         }
+        return filterAreas(configPtr, cachePtr, filter)
+    }
 
-        defer res.Body.Close() // Always close response body when done
-
-        val, err = io.ReadAll(res.Body)
+    pageValue, hasPage := flags["page"]
+    _, first := flags["first"]
+    _, last := flags["last"]
+    _, limitChanged := flags["limit"]
+    var offset int
+    switch {
+    case hasPage:
+        page, err := strconv.Atoi(pageValue)
+        if err != nil || page < 1 {
+            color.New(color.FgHiRed, color.Bold).Println("The page must be a number from 1 up.")
+            return nil
+        }
+        offset = (page - 1) * limit
+    case first:
+        offset = 0
+    case last:
+        total, err := totalAreas(configPtr, cachePtr)
         if err != nil {
             return err
         }
+        offset = lastPageOffset(total, limit)
+    case limitChanged:
+        // Show the current page again at its new size, starting on a page boundary
+        // so the page number and map/mapb keep lining up.
+        offset = configPtr.mapOffset / limit * limit
+    case !configPtr.mapShown:
+        offset = 0
+    default:
+        // Determine the next page from the one last shown
+        offset = configPtr.mapOffset + limit
+    }
 
-        if res.StatusCode > 299 {
-            // Log if response is an error
-            log.Fatalf("Response failed with status code: %d and\nbody: %s\n", res.StatusCode, val)
-        }
+    return showMapPage(configPtr, cachePtr, offset)
+}
 
-        // Store the raw byte response in the cache for next time
-        cachePtr.Add(url, val)
-    }    
-    var locations locations
-    err := json.Unmarshal(val, &locations)
+// commandMapB shows the previous page of Pokémon locations (or warns if on the first page) using the PokeAPI.
+// Results are cached for efficiency.
+func commandMapB(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	if !configPtr.mapShown || configPtr.mapOffset == 0 {
+		color.New(color.FgHiBlack).Println("You're on the first page...")
+		return nil
+	}
+
+	offset := configPtr.mapOffset - configPtr.pageLimit()
+	if offset < 0 {
+		offset = 0
+	}
+	return showMapPage(configPtr, cachePtr, offset)
+}
+
+
+// statusError is returned by fetchData when the PokeAPI answers with an error status,
+// e.g. 404 for a name that doesn't exist.
+type statusError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("request to %v failed with status code: %d", e.URL, e.StatusCode)
+}
+
+// isNotFound reports whether err is the PokeAPI not knowing a name, as opposed to
+// a network error or the PokeAPI failing.
+func isNotFound(err error) bool {
+	var se *statusError
+	return errors.As(err, &se) && se.StatusCode == http.StatusNotFound
+}
+
+// fetchData returns the raw response body for url, serving it from the cache when possible.
+// Unlike the paging commands it reports non-2xx responses as errors instead of exiting,
+// so a mistyped area or Pokémon name doesn't end the session.
+func fetchData(cachePtr *internal.Cache, url string) ([]byte, error) {
+    // Try to get the response data from the cache first.
+    val, ok := cachePtr.Get(url)
+    if ok {
+        return val, nil
+    }
+
+    // Not in cache! Make HTTP request to fetch data from the API
+    res, err := http.Get(url)
     if err != nil {
-        return err
+        return nil, err
     }
+    defer res.Body.Close() // Always close response body when done
 
-    err = json.Unmarshal(val, configPtr)
+    val, err = io.ReadAll(res.Body)
     if err != nil {
-        return err
+        return nil, err
     }
 
-    // Print the names of all locations in the page
-    // Highlight each location name in green!
-    for _, result := range locations.Results {
-        color.New(color.FgHiGreen, color.Bold).Printf("%v\n", result.Name)
+    if res.StatusCode > 299 {
+        return nil, &statusError{URL: url, StatusCode: res.StatusCode}
     }
 
-    return nil
+    // Store the raw byte response in the cache for next time
+    cachePtr.Add(url, val)
+    return val, nil
 }
 
-// commandMap shows the next page (or start) of Pokémon locations using the PokeAPI.
-// Results are cached for efficiency.
-func commandMapB(configPtr *config, cachePtr *internal.Cache, location string, pokedex map[string]pokemonDetails) error {
+// fetchLocationArea looks up a location area by name and returns its encounter details.
+func fetchLocationArea(cachePtr *internal.Cache, areaName string) (locationAreaDetails, error) {
+    var areaDetails locationAreaDetails
 
-	var url string
+    // Construct the API URL for the provided area name.
+    url := fmt.Sprintf("https://pokeapi.co/api/v2/location-area/%v/", areaName)
+    val, err := fetchData(cachePtr, url)
+    if err != nil {
+        return areaDetails, err
+    }
 
-	if configPtr.Previous == nil {
-		color.New(color.FgHiBlack).Println("You're on the first page...")
-		return nil
-	} else {
-		url = *configPtr.Previous
-	}
+    // Unmarshal HTTP response to extract Pokémon encounters from the JSON
+    err = json.Unmarshal(val, &areaDetails)
+    return areaDetails, err
+}
 
-    val, ok := cachePtr.Get(url)
-    if !ok {
-        // Not in cache! Make HTTP request to fetch data from the API
-        res, err := http.Get(url)
-        if err != nil {
-            return err
-        }
+// idFromURL returns the numeric ID at the end of a PokeAPI resource URL,
+// e.g. 25 for "https://pokeapi.co/api/v2/version-group/25/", or 0 if there is none.
+func idFromURL(url string) int {
+    parts := strings.Split(strings.TrimSuffix(url, "/"), "/")
+    id, err := strconv.Atoi(parts[len(parts)-1])
+    if err != nil {
+        return 0
+    }
+    return id
+}
 
-        defer res.Body.Close() // Always close response body when done
+// fetchPokemon looks up a Pokémon by name and returns its details.
+func fetchPokemon(cachePtr *internal.Cache, pokemonName string) (pokemonDetails, error) {
+    var pokemon pokemonDetails
 
-        val, err = io.ReadAll(res.Body)
-        if err != nil {
-            return err
-        }
+    url := fmt.Sprintf("https://pokeapi.co/api/v2/pokemon/%v/", pokemonName)
+    val, err := fetchData(cachePtr, url)
+    if err != nil {
+        return pokemon, err
+    }
 
-        if res.StatusCode > 299 {
-            // Log if response is an error
-            log.Fatalf("Response failed with status code: %d and\nbody: %s\n", res.StatusCode, val)
-        }
+    err = json.Unmarshal(val, &pokemon)
+    return pokemon, err
+}
 
-        // Store the raw byte response in the cache for next time
-        cachePtr.Add(url, val)
-    }
+// fetchSpecies looks up a Pokémon species by name and returns its species data.
+func fetchSpecies(cachePtr *internal.Cache, speciesName string) (pokemonSpecies, error) {
+    var species pokemonSpecies
 
-	locations := locations{}
+    url := fmt.Sprintf("https://pokeapi.co/api/v2/pokemon-species/%v/", speciesName)
+    val, err := fetchData(cachePtr, url)
+    if err != nil {
+        return species, err
+    }
 
-	err := json.Unmarshal(val, &locations)
-	if err != nil {
-		return err
-	}
+    err = json.Unmarshal(val, &species)
+    return species, err
+}
 
-	err = json.Unmarshal(val, configPtr)
-	if err != nil {
-		return err
-	}
+// fetchMove looks up a move by name and returns its battle data.
+func fetchMove(cachePtr *internal.Cache, moveName string) (moveDetails, error) {
+    var move moveDetails
 
-	for _, result := range locations.Results {
-		color.New(color.FgHiGreen, color.Bold).Printf("%v\n", result.Name)
-	}
+    url := fmt.Sprintf("https://pokeapi.co/api/v2/move/%v/", moveName)
+    val, err := fetchData(cachePtr, url)
+    if err != nil {
+        return move, err
+    }
 
-	return nil
+    err = json.Unmarshal(val, &move)
+    return move, err
 }
 
+// fetchType looks up a type by name and returns its damage relations.
+func fetchType(cachePtr *internal.Cache, typeName string) (typeDetails, error) {
+    var details typeDetails
 
-// commandMapB shows the previous page of Pokémon locations (or warns if on the first page) using the PokeAPI.
-func explore(configPtr *config, cachePtr *internal.Cache, areaName string, pokedex map[string]pokemonDetails) error {
-    // Construct the API URL for the provided area name.
-    url := fmt.Sprintf("https://pokeapi.co/api/v2/location-area/%v/", areaName)
-
+    url := fmt.Sprintf("https://pokeapi.co/api/v2/type/%v/", typeName)
+    val, err := fetchData(cachePtr, url)
+    if err != nil {
+        return details, err
+    }
 
-    // Try to get the response data from the cache first.
-    val, ok := cachePtr.Get(url)
-    if !ok {
-        // Not in cache! Make HTTP request to fetch data from the API
-        res, err := http.Get(url)
-        if err != nil {
-            return err
-        }
+    err = json.Unmarshal(val, &details)
+    return details, err
+}
 
-        defer res.Body.Close() // Always close response body when done
+// travel moves the trainer to a location area, which then limits what can be caught.
+func travel(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+    if len(args) == 0 {
+        color.New(color.FgHiRed, color.Bold).Println("Error: missing location argument.")
+        return nil
+    }
+    areaName := args[0]
+    if busyInBattle(configPtr) {
+        return nil
+    }
 
-        val, err = io.ReadAll(res.Body)
-        if err != nil {
-            return err
-        }
+    // Fetch the area first so we never end up standing in a place that doesn't exist.
+    if _, err := fetchLocationArea(cachePtr, areaName); err != nil {
+        return reportUnknown(configPtr, cachePtr, err, "area", areaName)
+    }
 
-        if res.StatusCode > 299 {
-            // Log if response is an error
-            log.Fatalf("Response failed with status code: %d and\nbody: %s\n", res.StatusCode, val)
-        }
+    configPtr.CurrentArea = areaName
+    configPtr.Encounter = nil // Wild Pokémon don't follow you to the next area
+    configPtr.unsaved = true
+    color.New(color.FgCyan, color.Bold).Printf("You travel to %s.\n", areaName)
+    color.New(color.FgCyan).Println("Use explore to look around for wild Pokémon.")
+    return nil
+}
 
-        // Store the raw byte response in the cache for next time
-        cachePtr.Add(url, val)
+// explore lists the wild Pokémon of a location area and makes it the current location.
+// Without an argument it explores the area the trainer is currently in.
+func explore(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+    areaName := configPtr.CurrentArea
+    if len(args) > 0 {
+        areaName = args[0]
     }
-    
-	// Unmarshal HTTP response to extract Pokémon encounters from the JSON
-    var areaDetails locationAreaDetails
-    err := json.Unmarshal(val, &areaDetails)
+    if areaName == "" {
+        color.New(color.FgHiRed, color.Bold).Println("Error: missing location argument (or travel somewhere first).")
+        return nil
+    }
+
+    areaDetails, err := fetchLocationArea(cachePtr, areaName)
     if err != nil {
-        return err
+        return reportUnknown(configPtr, cachePtr, err, "area", areaName)
+    }
+    if areaName != configPtr.CurrentArea {
+        if busyInBattle(configPtr) {
+            return nil
+        }
+        configPtr.CurrentArea = areaName
+        configPtr.Encounter = nil
+        configPtr.unsaved = true
     }
+    configPtr.recordExplored(areaName)
+
 	color.New(color.FgCyan, color.Bold).Printf(
     "You venture into %s...\nThese wild Pokémon can be found here:\n",
     areaName,
 	)
-    // Each wild Pokémon in magenta and bold
-    for _, result := range areaDetails.PokemonEncounters {
-        color.New(color.FgHiMagenta, color.Bold).Printf(" - %v\n", result.Pokemon.Name)
+    // Each wild Pokémon in magenta and bold, skipping those not found in the selected game
+    for i, result := range areaDetails.PokemonEncounters {
+        if !inVersion(encounterVersionNames(areaDetails, i), configPtr.Version) {
+            continue
+        }
+        color.New(color.FgHiMagenta, color.Bold).Printf(" - %v", result.Pokemon.Name)
+        // A shiny one met by walk, surf or fish is still around
+        if configPtr.Encounter != nil && configPtr.Encounter.Shiny && configPtr.Encounter.Pokemon == result.Pokemon.Name {
+            color.New(color.FgHiYellow, color.Bold).Print("  ★ a shiny one is nearby!")
+        }
+        fmt.Println()
+        configPtr.markSeen(result.Pokemon.Name)
     }
 	fmt.Println()
 
     return nil
 }
 
+// canEncounter reports whether pokemonName is among the wild encounters of an area
+// in the given version ("" for any version).
+func canEncounter(areaDetails locationAreaDetails, pokemonName string, version string) bool {
+    for i, encounter := range areaDetails.PokemonEncounters {
+        if encounter.Pokemon.Name == pokemonName && inVersion(encounterVersionNames(areaDetails, i), version) {
+            return true
+        }
+    }
+    return false
+}
+
 
 // catch attempts to catch a Pokémon by name, using a probability based on base experience.
+// Only Pokémon found in the current location area can be caught, unless --cheat is given.
+// --form <form> catches a variety of the species, e.g. catch vulpix --form alola.
 // If caught, adds the Pokémon to the user's Pokedex.
-func catch(configPtr *config, cachePtr *internal.Cache, pokemonName string, pokedex map[string]pokemonDetails) error {
-
-    // Message indicating which pokemon we are trying to catch
-	pokemonName = strings.ToLower(pokemonName)
-	color.New(color.Bold).Printf("Throwing a Pokéball at %v...\n", pokemonName)
-
-	
-	// Construct the API URL for the provided area name.
-    url := fmt.Sprintf("https://pokeapi.co/api/v2/pokemon/%v/", pokemonName)
+func catch(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+    // In a battle the Pokéball is thrown at the Pokémon being fought.
+    if configPtr.Battle != nil {
+        return battleCatch(configPtr, cachePtr, pokedex)
+    }
 
-    // Try to get the response data from the cache first.
-    val, ok := cachePtr.Get(url)
+    positional, flags := parseFlags(args, "cheat")
+    // Without a name, throw at the wild Pokémon met by walk/surf/fish.
+    if len(positional) == 0 && configPtr.Encounter != nil {
+        positional = append(positional, configPtr.Encounter.Pokemon)
+    }
+    if len(positional) == 0 {
+        color.New(color.FgHiRed, color.Bold).Println("Error: missing pokemon argument.")
+        return nil
+    }
+	pokemonName := strings.ToLower(positional[0])
+    requested := pokemonName
+    defaultForm := false // Whether --form picked the species' default variety
 
-    if !ok {
-        // Not in cache! Make HTTP request to fetch data from the API
-        res, err := http.Get(url)
+    // --form alola catches the Alolan variety of the species, and so on.
+    if form, ok := flags["form"]; ok {
+        species, err := lookupSpecies(cachePtr, pokemonName)
         if err != nil {
             return err
         }
+        variety, found := resolveForm(species, form)
+        if !found {
+            color.New(color.FgHiRed, color.Bold).Printf("%v has no %v form. Its forms are: %v\n", species.Name, form, strings.Join(varietyForms(species), ", "))
+            return nil
+        }
+        pokemonName, defaultForm = variety, variety == species.defaultPokemon()
+    }
 
-        defer res.Body.Close() // Always close response body when done
-
-        val, err = io.ReadAll(res.Body)
+    // Without --cheat the Pokémon has to actually live where the trainer is standing.
+    _, cheat := flags["cheat"]
+    if !cheat && configPtr.CurrentArea == "" {
+        color.New(color.FgHiRed, color.Bold).Println("You are not in any area yet. Use travel <area> first.")
+        return nil
+    }
+    var areaDetails locationAreaDetails
+    if configPtr.CurrentArea != "" {
+        var err error
+        areaDetails, err = fetchLocationArea(cachePtr, configPtr.CurrentArea)
         if err != nil {
             return err
         }
-
-        if res.StatusCode > 299 {
-            // Log if response is an error
-            log.Fatalf("Response failed with status code: %d and\nbody: %s\n", res.StatusCode, val)
+    }
+    // The rolled wild encounter may be from another game (walk --game red).
+    version := configPtr.Version
+    if configPtr.Encounter != nil && configPtr.Encounter.Pokemon == requested {
+        version = configPtr.Encounter.Version
+    }
+    // Encounter tables list the default form by species name (deoxys for deoxys-normal);
+    // any other form has to be listed itself.
+    livesHere := canEncounter(areaDetails, pokemonName, version) ||
+        (defaultForm && canEncounter(areaDetails, requested, version))
+    if !cheat && !livesHere {
+        color.New(color.FgHiRed, color.Bold).Printf("There is no wild %v in %v.\n", pokemonName, configPtr.CurrentArea)
+        // Most likely a typo of something that does live here
+        wild := []string{}
+        for i, encounter := range areaDetails.PokemonEncounters {
+            if inVersion(encounterVersionNames(areaDetails, i), version) {
+                wild = append(wild, encounter.Pokemon.Name)
+            }
         }
-
-        // Store the raw byte response in the cache for next time
-        cachePtr.Add(url, val)
+        printDidYouMean(similarNames(pokemonName, wild))
+        return nil
     }
-    
-	// Unmarshal HTTP response to extract Pokémon encounters from the JSON
-    var pokemon pokemonDetails
-    err := json.Unmarshal(val, &pokemon)
+
+    // Message indicating which pokemon we are trying to catch
+	color.New(color.Bold).Printf("Throwing a Pokéball at %v...\n", pokemonName)
+
+    pokemon, err := fetchPokemon(cachePtr, pokemonName)
     if err != nil {
-        return err
+        return reportUnknown(configPtr, cachePtr, err, "pokemon", pokemonName)
     }
 
-	chance := 1 - (float64(pokemon.BaseExperience) / float64(maxBaseExp))
-	if chance < 0 {
-		chance = 0.01
-	}
-	if chance > 1 {
-		chance = 0.99
+	owned, where, err := throwPokeball(configPtr, cachePtr, pokemon, areaDetails, pokedex)
+	if err != nil {
+		return err
 	}
-	randomFloat := rand.Float64()
-	if randomFloat < chance {
-		pokedex[pokemonName] = pokemon
-		color.New(color.FgHiGreen, color.Bold).Printf("%v was caught!\n", pokemonName)
+	if owned != nil {
+		color.New(color.FgHiGreen, color.Bold).Printf("%v was caught! (#%d, Lv. %d, sent to %v)\n", pokemonName, owned.ID, owned.Level, where)
+		if owned.Shiny {
+			color.New(color.FgHiYellow, color.Bold).Println("★ It's shiny! ★")
+		}
         color.New(color.FgCyan).Println("You may now inspect it with the inspect command.")
 	} else {
 		color.New(color.FgHiRed, color.Bold).Println("Missed catch!")
@@ -638,15 +1150,101 @@ func catch(configPtr *config, cachePtr *internal.Cache, pokemonName string, poke
 
 
 
+// throwPokeball tries to catch a Pokémon met in areaDetails, with a probability based on
+// its base experience. On success the Pokémon is stored and registered in the pokedex,
+// and it is returned with where it was sent; when it gets away the result is nil.
+func throwPokeball(configPtr *config, cachePtr *internal.Cache, pokemon pokemonDetails, areaDetails locationAreaDetails, pokedex map[string]pokemonDetails) (*ownedPokemon, string, error) {
+	chance := 1 - (float64(pokemon.BaseExperience) / float64(maxBaseExp))
+	if chance < 0 {
+		chance = 0.01
+	}
+	if chance > 1 {
+		chance = 0.99
+	}
+	if rand.Float64() >= chance {
+		configPtr.recordThrow(false)
+		return nil, "", nil
+	}
+
+	species, err := fetchSpecies(cachePtr, pokemon.Species.Name)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// The pokedex keeps the species data; the trainer keeps the individual.
+	// Nothing is registered until the Pokémon is safely stored, so a failed
+	// request or a full PC doesn't leave a caught species with no one behind it.
+	pokemonName := pokemon.Name
+	level := encounterLevel(configPtr, areaDetails, pokemon, encounterRNG)
+	owned := newOwnedPokemon(configPtr.NextID+1, pokemonName, level, species.GenderRate, encounterShiny(configPtr, pokemon, encounterRNG), configPtr.CurrentArea, encounterRNG)
+	if err := initProgress(cachePtr, owned, pokemon, configPtr.VersionGroup); err != nil {
+		return nil, "", err
+	}
+	where, err := configPtr.storeCaught(owned)
+	if err != nil {
+		return nil, "", err
+	}
+	configPtr.NextID++
+	pokedex[pokemonName] = pokemon
+	configPtr.markSeen(pokemonName)
+	configPtr.recordThrow(true)
+	configPtr.unsaved = true
+
+	if configPtr.encounterIs(pokemon) {
+		configPtr.Encounter = nil // The wild Pokémon is no longer out there
+	}
+	return owned, where, nil
+}
+
+
 // inspect displays detailed information about a caught Pokémon.
+// The argument is either a species name or an owned Pokémon's #ID or nickname;
+// individuals are shown with their computed stats. --moves [--version-group <name>]
+// and --abilities add the species' learnable moves and abilities; --no-sprite skips the picture.
 // If the user hasn't caught this Pokémon yet, prints a message.
-func inspect(configPtr *config, cachePtr *internal.Cache, pokemonName string, pokedex map[string]pokemonDetails) error {
+func inspect(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+    positional, flags := parseFlags(args, "moves", "abilities", "no-sprite")
+    if len(positional) == 0 {
+        color.New(color.FgHiRed, color.Bold).Println("Error: missing pokemon argument.")
+        return nil
+    }
+    pokemonName := positional[0]
+
     foundPokemon, ok := pokedex[pokemonName]
+    if !ok {
+        // A specific individual, by #ID or nickname
+        if owned, found := findOwned(configPtr, pokemonName); found {
+            printInspectSprite(configPtr, cachePtr, pokedex[owned.Species], owned.Shiny, flags)
+            printOwned(owned, pokedex[owned.Species])
+            printInspectSections(configPtr, pokedex[owned.Species], flags)
+            return nil
+        }
+    }
     if ok {
+        printInspectSprite(configPtr, cachePtr, foundPokemon, false, flags)
         // Name header
-        color.New(color.FgHiYellow, color.Bold).Printf("Name: %v\n", foundPokemon.Name)
+        color.New(color.FgHiYellow, color.Bold).Printf("Name: %v", foundPokemon.Name)
+        for _, owned := range ownedOfSpecies(configPtr, pokemonName) {
+            if owned.Shiny {
+                color.New(color.FgHiYellow, color.Bold).Print("  ★ shiny one owned")
+                break
+            }
+        }
+        fmt.Println()
+        // Forms are tracked on their own; say which species this one belongs to.
+        if speciesName := foundPokemon.Species.Name; speciesName != "" && speciesName != foundPokemon.Name {
+            color.New(color.FgHiBlack).Printf("Form: %v of %v\n", formName(speciesName, foundPokemon.Name), speciesName)
+        }
         color.New(color.Bold).Printf("Height: %v\nWeight: %v\n", foundPokemon.Height, foundPokemon.Weight)
 
+        // The Pokédex entry of the selected game (skipped if the species can't be fetched)
+        if species, err := fetchSpecies(cachePtr, foundPokemon.Species.Name); err == nil {
+            if text, from := flavorText(species, configPtr.Version); text != "" {
+                color.New(color.FgWhite).Printf("%v ", text)
+                color.New(color.FgHiBlack).Printf("(pokemon %v)\n", from)
+            }
+        }
+
         color.New(color.FgCyan, color.Bold).Println("Stats:")
         for _, value := range foundPokemon.Stats {
             statColor := color.New(color.Bold)
@@ -672,111 +1270,130 @@ func inspect(configPtr *config, cachePtr *internal.Cache, pokemonName string, po
         color.New(color.FgCyan, color.Bold).Println("Types:")
         for _, value := range foundPokemon.Types {
             typeName := value.Type.Name
-            typeColor := color.New(color.Bold)
-            switch typeName {
-            case "fire":
-                typeColor.Add(color.FgHiRed)
-            case "water":
-                typeColor.Add(color.FgHiCyan)
-            case "grass":
-                typeColor.Add(color.FgHiGreen)
-            case "electric":
-                typeColor.Add(color.FgHiYellow)
-            case "psychic":
-                typeColor.Add(color.FgMagenta)
-            case "bug":
-                typeColor.Add(color.FgGreen)
-            case "normal":
-                typeColor.Add(color.FgWhite)
-            case "fighting":
-                typeColor.Add(color.FgRed)
-            case "poison":
-                typeColor.Add(color.FgMagenta)
-            case "ground":
-                typeColor.Add(color.FgYellow)
-            case "flying":
-                typeColor.Add(color.FgHiBlue)
-            case "rock":
-                typeColor.Add(color.FgHiWhite)
-            case "ghost":
-                typeColor.Add(color.FgHiMagenta)
-            case "ice":
-                typeColor.Add(color.FgCyan)
-            case "dragon":
-                typeColor.Add(color.FgBlue)
-            case "dark":
-                typeColor.Add(color.FgBlack)
-            case "steel":
-                typeColor.Add(color.FgHiWhite)
-            case "fairy":
-                typeColor.Add(color.FgHiMagenta)
-            default:
-                typeColor.Add(color.FgCyan)
+            typeColor(typeName).Printf("  - %v\n", typeName)
+        }
+
+        // The individuals of this species the trainer owns
+        owned := ownedOfSpecies(configPtr, pokemonName)
+        if len(owned) == 1 {
+            fmt.Println()
+            printOwned(owned[0], foundPokemon)
+        } else if len(owned) > 1 {
+            color.New(color.FgCyan, color.Bold).Println("Owned (inspect #ID for details):")
+            for _, individual := range owned {
+                color.New(color.Bold).Printf("  - #%d %v, Lv. %d\n", individual.ID, individual.displayName(), individual.Level)
             }
-            typeColor.Printf("  - %v\n", typeName)
         }
+        printInspectSections(configPtr, foundPokemon, flags)
     } else {
         color.New(color.FgHiRed, color.Bold).Printf("You have not yet caught %v\n", pokemonName)
+        // Other forms of the species may be caught, e.g. vulpix-alola for vulpix.
+        if caught := caughtForms(pokedex, pokemonName); len(caught) > 0 {
+            color.New(color.FgHiBlack).Printf("You have caught these forms of it: %v\n", strings.Join(caught, ", "))
+        } else if !printDidYouMean(similarNames(pokemonName, sortedPokedexNames(pokedex))) {
+            printDidYouMean(similarNames(pokemonName, knownNames(configPtr, cachePtr, "pokemon")))
+        }
     }
     return nil
 }
 
-// pokedex lists all caught Pokémon names in the user's personal Pokedex.
-func pokedex(configPtr *config, cachePtr *internal.Cache, pokemonName string, pokedex map[string]pokemonDetails) error {
-    if len(pokedex) > 0 {
-        color.New(color.FgCyan, color.Bold).Println("Your Pokedex:")
-        for key, details := range pokedex {
-            typeColor := color.New(color.Bold)
-            if len(details.Types) > 0 {
-                typeName := details.Types[0].Type.Name
-                switch typeName {
-                case "fire":
-                    typeColor.Add(color.FgHiRed)
-                case "water":
-                    typeColor.Add(color.FgHiCyan)
-                case "grass":
-                    typeColor.Add(color.FgHiGreen)
-                case "electric":
-                    typeColor.Add(color.FgHiYellow)
-                case "psychic":
-                    typeColor.Add(color.FgMagenta)
-                case "bug":
-                    typeColor.Add(color.FgGreen)
-                case "normal":
-                    typeColor.Add(color.FgWhite)
-                case "fighting":
-                    typeColor.Add(color.FgRed)
-                case "poison":
-                    typeColor.Add(color.FgMagenta)
-                case "ground":
-                    typeColor.Add(color.FgYellow)
-                case "flying":
-                    typeColor.Add(color.FgHiBlue)
-                case "rock":
-                    typeColor.Add(color.FgHiWhite)
-                case "ghost":
-                    typeColor.Add(color.FgHiMagenta)
-                case "ice":
-                    typeColor.Add(color.FgCyan)
-                case "dragon":
-                    typeColor.Add(color.FgBlue)
-                case "dark":
-                    typeColor.Add(color.FgHiBlack)
-                case "steel":
-                    typeColor.Add(color.FgHiWhite)
-                case "fairy":
-                    typeColor.Add(color.FgHiMagenta)
-                default:
-                    typeColor.Add(color.FgCyan)
-                }
-            } else {
-                typeColor.Add(color.FgHiGreen) // fallback for unknown type
-            }
-			color.New(color.Bold).Print("- ")
-            typeColor.Printf("%v\n", key)
+// pokedex lists all caught Pokémon in columns, marking how many of each are still owned,
+// followed by species only seen so far. --sort, --type, --gen and --shiny order and filter
+// the list, which is shown --limit species at a time (--page <n>).
+// --national lists the whole national dex with seen/caught marks, --missing only what
+// isn't caught yet, and --completion the progress per generation.
+func pokedex(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+    _, flags := parseFlags(args, "national", "missing", "completion", "shiny")
+    typeName, typeFilter := flags["type"]
+    if typeFilter && !contains(typeNames, typeName) {
+        color.New(color.FgHiRed, color.Bold).Printf("Unknown type %q. Types are: %v\n", typeName, strings.Join(typeNames, ", "))
+        return nil
+    }
+    if _, ok := flags["completion"]; ok {
+        return showDexCompletion(configPtr, cachePtr, pokedex)
+    }
+    _, national := flags["national"]
+    _, missing := flags["missing"]
+    if national || missing {
+        return showNationalDex(configPtr, cachePtr, pokedex, typeName, missing)
+    }
+
+    sortBy := "id"
+    if value, ok := flags["sort"]; ok {
+        if !contains(dexSorts, value) {
+            color.New(color.FgHiRed, color.Bold).Printf("Unknown sort %q. Sort by %v.\n", value, strings.Join(dexSorts, ", "))
+            return nil
         }
-    } else {
+        sortBy = value
+    }
+    var generation *internal.Generation
+    if value, ok := flags["gen"]; ok {
+        found, known := parseGeneration(value)
+        if !known {
+            color.New(color.FgHiRed, color.Bold).Printf("Unknown generation %q. Use 1 to %d or a region name.\n", value, len(internal.Generations))
+            return nil
+        }
+        generation = &found
+    }
+    limit := defaultDexLimit
+    if value, ok := flags["limit"]; ok {
+        parsed, err := strconv.Atoi(value)
+        if err != nil || parsed < 1 {
+            color.New(color.FgHiRed, color.Bold).Println("The limit must be a number from 1 up.")
+            return nil
+        }
+        limit = parsed
+    }
+    page := 1
+    if value, ok := flags["page"]; ok {
+        parsed, err := strconv.Atoi(value)
+        if err != nil || parsed < 1 {
+            color.New(color.FgHiRed, color.Bold).Println("The page must be a number from 1 up.")
+            return nil
+        }
+        page = parsed
+    }
+
+    if len(pokedex) == 0 && len(configPtr.Seen) == 0 {
         color.New(color.FgHiMagenta, color.Bold).Println("No Pokémon in the Pokedex yet... Gotta catch 'em all!!")
+        return nil
+    }
+
+    ownedCount := len(configPtr.allOwned())
+    color.New(color.FgCyan, color.Bold).Println("Your Pokedex:")
+    color.New(color.FgHiBlack).Printf("Seen: %d  Caught: %d  Owned: %d\n", len(configPtr.Seen), len(pokedex), ownedCount)
+
+    _, shinyOnly := flags["shiny"]
+    entries := filterCaught(caughtEntries(configPtr, pokedex), typeName, generation, shinyOnly)
+    sortCaught(entries, sortBy)
+    pages := pageCount(len(entries), limit)
+    switch {
+    case len(entries) == 0 && len(pokedex) > 0:
+        color.New(color.FgHiBlack).Println("No caught Pokémon match.")
+    case page > pages:
+        color.New(color.FgHiBlack).Printf("There are only %d pages.\n", pages)
+        return nil
+    case len(entries) > 0:
+        printCaughtGrid(entries[(page-1)*limit : min(page*limit, len(entries))])
+        if pages > 1 {
+            color.New(color.FgHiBlack).Printf("Page %d of %d (%d species)\n", page, pages, len(entries))
+        }
+    }
+
+    // Seen in the wild, but never caught; only after the last page of the unfiltered list
+    if typeFilter || generation != nil || shinyOnly || page < pages {
+        return nil
+    }
+    seenOnly := []string{}
+    for species := range configPtr.Seen {
+        if _, caught := pokedex[species]; !caught {
+            seenOnly = append(seenOnly, species)
+        }
+    }
+    if len(seenOnly) > 0 {
+        sort.Strings(seenOnly)
+        color.New(color.FgCyan, color.Bold).Println("Seen but not caught:")
+        color.New(color.FgHiBlack).Printf("  %v\n", strings.Join(seenOnly, ", "))
     }
     return nil
 }
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
+	"github.com/fatih/color"
+)
+
+// compareBarWidth is the width of the longest bar in compare's bar charts.
+const compareBarWidth = 30
+
+// compareColors tell the Pokémon apart in the bar charts.
+var compareColors = []color.Attribute{color.FgHiRed, color.FgHiCyan, color.FgHiGreen, color.FgHiYellow, color.FgHiMagenta, color.FgHiBlue}
+
+// comparedPokemon is one column of compare: a species with its base stats,
+// or an owned Pokémon with its actual stats.
+type comparedPokemon struct {
+	label   string
+	details pokemonDetails
+	stats   map[string]int
+}
+
+// resolveCompared looks up a compare argument. An owned Pokémon's #ID or nickname
+// gives that individual; any other name is a species, caught or not.
+// It reports false after printing a message when nothing is found.
+func resolveCompared(configPtr *config, cachePtr *internal.Cache, ref string, pokedex map[string]pokemonDetails) (comparedPokemon, bool, error) {
+	if owned, found := findOwned(configPtr, ref); found && owned.Species != ref {
+		details := pokedex[owned.Species]
+		label := fmt.Sprintf("%v Lv.%d", owned.displayName(), owned.Level)
+		return comparedPokemon{label: label, details: details, stats: owned.actualStats(details)}, true, nil
+	}
+
+	details, ok := pokedex[ref]
+	if !ok {
+		var err error
+		details, err = fetchPokemon(cachePtr, ref)
+		if err != nil {
+			return comparedPokemon{}, false, reportUnknown(configPtr, cachePtr, err, "pokemon", ref)
+		}
+	}
+
+	stats := make(map[string]int)
+	for _, value := range details.Stats {
+		stats[value.Stat.Name] = value.BaseStat
+	}
+	return comparedPokemon{label: details.Name, details: details, stats: stats}, true, nil
+}
+
+// highestColumns returns which values are the highest of a row.
+// When all values are equal none of them stands out, so none is returned.
+func highestColumns(values []int) map[int]bool {
+	highest := map[int]bool{}
+	if len(values) == 0 {
+		return highest
+	}
+	top, allEqual := values[0], true
+	for _, value := range values {
+		if value != values[0] {
+			allEqual = false
+		}
+		if value > top {
+			top = value
+		}
+	}
+	if allEqual {
+		return highest
+	}
+	for col, value := range values {
+		if value == top {
+			highest[col] = true
+		}
+	}
+	return highest
+}
+
+// statBar returns a bar of full blocks whose length is value's share of maxValue in width.
+// Any value above zero gets at least one block.
+func statBar(value, maxValue, width int) string {
+	if value <= 0 || maxValue <= 0 {
+		return ""
+	}
+	length := (value*width + maxValue/2) / maxValue
+	if length < 1 {
+		length = 1
+	}
+	if length > width {
+		length = width
+	}
+	return strings.Repeat("█", length)
+}
+
+// compare shows the stats, types, size and abilities of two or more Pokémon side by side,
+// highlighting the highest value of each row, followed by a bar chart per stat.
+// Arguments are species names, caught or not, or an owned Pokémon's #ID or nickname,
+// which is compared with its actual stats.
+func compare(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	if len(args) < 2 {
+		color.New(color.FgHiRed, color.Bold).Println("Error: compare needs at least two Pokémon.")
+		return nil
+	}
+	if len(args) > len(compareColors) {
+		color.New(color.FgHiRed, color.Bold).Printf("Error: compare takes at most %d Pokémon.\n", len(compareColors))
+		return nil
+	}
+
+	compared := []comparedPokemon{}
+	for _, ref := range args {
+		entry, found, err := resolveCompared(configPtr, cachePtr, ref, pokedex)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return nil
+		}
+		compared = append(compared, entry)
+	}
+
+	headers := []string{""}
+	for _, entry := range compared {
+		headers = append(headers, entry.label)
+	}
+
+	// Numeric rows, compared to find the highest value
+	type numericRow struct {
+		name   string
+		values []int
+		format func(int) string
+	}
+	plain := func(value int) string { return fmt.Sprint(value) }
+	numeric := []numericRow{}
+	totals := make([]int, len(compared))
+	for _, stat := range internal.StatNames {
+		row := numericRow{name: stat, format: plain}
+		for i, entry := range compared {
+			row.values = append(row.values, entry.stats[stat])
+			totals[i] += entry.stats[stat]
+		}
+		numeric = append(numeric, row)
+	}
+	numeric = append(numeric, numericRow{name: "total", values: totals, format: plain})
+	heights, weights := []int{}, []int{}
+	for _, entry := range compared {
+		heights = append(heights, entry.details.Height)
+		weights = append(weights, entry.details.Weight)
+	}
+	// The PokeAPI gives height in decimetres and weight in hectograms.
+	numeric = append(numeric,
+		numericRow{name: "height", values: heights, format: func(value int) string { return fmt.Sprintf("%.1f m", float64(value)/10) }},
+		numericRow{name: "weight", values: weights, format: func(value int) string { return fmt.Sprintf("%.1f kg", float64(value)/10) }},
+	)
+
+	rows := [][]string{}
+	highlights := []map[int]bool{}
+	for _, row := range numeric {
+		cells := []string{row.name}
+		for _, value := range row.values {
+			cells = append(cells, row.format(value))
+		}
+		rows = append(rows, cells)
+		highlights = append(highlights, highestColumns(row.values))
+	}
+
+	typesRow, abilitiesRow := []string{"types"}, []string{"abilities"}
+	for _, entry := range compared {
+		types := []string{}
+		for _, t := range entry.details.Types {
+			types = append(types, t.Type.Name)
+		}
+		typesRow = append(typesRow, strings.Join(types, "/"))
+
+		abilities := []string{}
+		for _, a := range entry.details.Abilities {
+			name := a.Ability.Name
+			if a.IsHidden {
+				name += " (H)"
+			}
+			abilities = append(abilities, name)
+		}
+		abilitiesRow = append(abilitiesRow, strings.Join(abilities, ", "))
+	}
+	rows = append(rows, typesRow, abilitiesRow)
+
+	printTable(headers, rows, func(row, col int) *color.Color {
+		if col == 0 {
+			return color.New(color.FgCyan)
+		}
+		if row < len(highlights) && highlights[row][col-1] {
+			return color.New(color.FgHiGreen, color.Bold)
+		}
+		return nil
+	})
+
+	// Bar charts, all on the same scale so bars of different stats compare too
+	maxValue := 0
+	labelWidth := 0
+	for _, entry := range compared {
+		for _, stat := range internal.StatNames {
+			maxValue = max(maxValue, entry.stats[stat])
+		}
+		labelWidth = max(labelWidth, utf8.RuneCountInString(entry.label))
+	}
+	for _, stat := range internal.StatNames {
+		fmt.Println()
+		color.New(color.FgCyan, color.Bold).Println(stat)
+		for i, entry := range compared {
+			label := entry.label + strings.Repeat(" ", labelWidth-utf8.RuneCountInString(entry.label))
+			fmt.Printf("  %v ", label)
+			color.New(compareColors[i]).Print(statBar(entry.stats[stat], maxValue, compareBarWidth))
+			fmt.Printf(" %d\n", entry.stats[stat])
+		}
+	}
+	return nil
+}
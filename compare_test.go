@@ -0,0 +1,47 @@
+package main
+
+import "testing"
+
+// TestHighestColumns checks which values compare highlights, including ties.
+func TestHighestColumns(t *testing.T) {
+	cases := []struct {
+		values   []int
+		expected []int
+	}{
+		{values: []int{45, 60, 80}, expected: []int{2}},
+		{values: []int{100, 50, 100}, expected: []int{0, 2}},
+		{values: []int{70, 70}, expected: []int{}},
+	}
+	for _, c := range cases {
+		actual := highestColumns(c.values)
+		if len(actual) != len(c.expected) {
+			t.Errorf("%v: Expected %v, Got %v", c.values, c.expected, actual)
+			continue
+		}
+		for _, col := range c.expected {
+			if !actual[col] {
+				t.Errorf("%v: Expected column %d highlighted, Got %v", c.values, col, actual)
+			}
+		}
+	}
+}
+
+// TestStatBar checks bar lengths, rounding and the one-block minimum.
+func TestStatBar(t *testing.T) {
+	cases := []struct {
+		value    int
+		maxValue int
+		expected int
+	}{
+		{value: 100, maxValue: 100, expected: 30},
+		{value: 50, maxValue: 100, expected: 15},
+		{value: 1, maxValue: 255, expected: 1},
+		{value: 0, maxValue: 100, expected: 0},
+	}
+	for _, c := range cases {
+		bar := []rune(statBar(c.value, c.maxValue, 30))
+		if len(bar) != c.expected {
+			t.Errorf("%d of %d: Expected %d blocks, Got %d", c.value, c.maxValue, c.expected, len(bar))
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
+	"github.com/fatih/color"
+)
+
+// defaultDexLimit is how many species a pokedex page shows unless --limit says otherwise.
+const defaultDexLimit = 60
+
+// dexSorts lists the orders pokedex --sort accepts.
+var dexSorts = []string{"id", "name", "type", "bst", "caught-at"}
+
+// caughtEntry is one caught species in the pokedex listing.
+type caughtEntry struct {
+	dexRow
+	Species     int       // National dex number of the species, for --gen
+	FirstCaught time.Time // When the first still-owned one was caught; zero if all were released
+	Shiny       bool      // Whether a shiny one is owned
+}
+
+// speciesNumber returns the national dex number of a Pokémon's species, which forms
+// such as vulpix-alola share with their species.
+func speciesNumber(details pokemonDetails) int {
+	if id := idFromURL(details.Species.URL); id > 0 {
+		return id
+	}
+	return details.ID
+}
+
+// caughtEntries builds the listing entries of every caught species, in national dex order.
+func caughtEntries(configPtr *config, pokedex map[string]pokemonDetails) []caughtEntry {
+	entries := []caughtEntry{}
+	for _, row := range dexRows(configPtr, pokedex) {
+		details := pokedex[row.Name]
+		entry := caughtEntry{dexRow: row, Species: speciesNumber(details)}
+		for _, owned := range ownedOfSpecies(configPtr, row.Name) {
+			if entry.FirstCaught.IsZero() || owned.CaughtAt.Before(entry.FirstCaught) {
+				entry.FirstCaught = owned.CaughtAt
+			}
+			entry.Shiny = entry.Shiny || owned.Shiny
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// compareCaught compares two entries by one of dexSorts, returning a negative
+// number when a comes first and 0 when the order doesn't decide.
+func compareCaught(a, b caughtEntry, by string) int {
+	switch by {
+	case "name":
+		return strings.Compare(a.Name, b.Name)
+	case "type":
+		return strings.Compare(firstType(a), firstType(b))
+	case "bst":
+		// Strongest first
+		return b.Total - a.Total
+	case "caught-at":
+		// Oldest catch first; released species, whose catch time is gone, last
+		if a.FirstCaught.IsZero() || b.FirstCaught.IsZero() {
+			if a.FirstCaught.IsZero() == b.FirstCaught.IsZero() {
+				return 0
+			}
+			if a.FirstCaught.IsZero() {
+				return 1
+			}
+			return -1
+		}
+		return a.FirstCaught.Compare(b.FirstCaught)
+	}
+	return 0
+}
+
+// sortCaught orders entries by one of dexSorts, with ties in national dex order
+// and the forms of a species right after its default form.
+func sortCaught(entries []caughtEntry, by string) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if order := compareCaught(entries[i], entries[j], by); order != 0 {
+			return order < 0
+		}
+		if entries[i].Species != entries[j].Species {
+			return entries[i].Species < entries[j].Species
+		}
+		return entries[i].ID < entries[j].ID
+	})
+}
+
+// firstType returns the primary type of an entry, or "" if it has none.
+func firstType(entry caughtEntry) string {
+	if len(entry.Types) == 0 {
+		return ""
+	}
+	return entry.Types[0]
+}
+
+// parseGeneration finds a generation by number ("1") or region name ("kanto").
+func parseGeneration(value string) (internal.Generation, bool) {
+	for _, generation := range internal.Generations {
+		if value == strconv.Itoa(generation.Number) || value == generation.Region {
+			return generation, true
+		}
+	}
+	return internal.Generation{}, false
+}
+
+// filterCaught keeps the entries of typeName (unless ""), of generation (unless nil),
+// and only those with a shiny one owned if shinyOnly is set.
+func filterCaught(entries []caughtEntry, typeName string, generation *internal.Generation, shinyOnly bool) []caughtEntry {
+	filtered := []caughtEntry{}
+	for _, entry := range entries {
+		if typeName != "" && !contains(entry.Types, typeName) {
+			continue
+		}
+		if generation != nil && (entry.Species < generation.First || entry.Species > generation.Last) {
+			continue
+		}
+		if shinyOnly && !entry.Shiny {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+	return filtered
+}
+
+// typeColor returns the color typeName is shown in, by inspect and the pokedex listing.
+func typeColor(typeName string) *color.Color {
+	typeColor := color.New(color.Bold)
+	switch typeName {
+	case "fire":
+		typeColor.Add(color.FgHiRed)
+	case "water":
+		typeColor.Add(color.FgHiCyan)
+	case "grass":
+		typeColor.Add(color.FgHiGreen)
+	case "electric":
+		typeColor.Add(color.FgHiYellow)
+	case "psychic":
+		typeColor.Add(color.FgMagenta)
+	case "bug":
+		typeColor.Add(color.FgGreen)
+	case "normal":
+		typeColor.Add(color.FgWhite)
+	case "fighting":
+		typeColor.Add(color.FgRed)
+	case "poison":
+		typeColor.Add(color.FgMagenta)
+	case "ground":
+		typeColor.Add(color.FgYellow)
+	case "flying":
+		typeColor.Add(color.FgHiBlue)
+	case "rock":
+		typeColor.Add(color.FgHiWhite)
+	case "ghost":
+		typeColor.Add(color.FgHiMagenta)
+	case "ice":
+		typeColor.Add(color.FgCyan)
+	case "dragon":
+		typeColor.Add(color.FgBlue)
+	case "dark":
+		typeColor.Add(color.FgBlack)
+	case "steel":
+		typeColor.Add(color.FgHiWhite)
+	case "fairy":
+		typeColor.Add(color.FgHiMagenta)
+	case "":
+		typeColor.Add(color.FgHiGreen) // fallback for unknown type
+	default:
+		typeColor.Add(color.FgCyan)
+	}
+	return typeColor
+}
+
+// caughtCell is the text of an entry in the grid: number, name, how many are owned
+// (or "released") and a star if one of them is shiny.
+func caughtCell(entry caughtEntry) string {
+	cell := fmt.Sprintf("#%04d %v", entry.Species, entry.Name)
+	if entry.Owned > 0 {
+		cell += fmt.Sprintf(" x%d", entry.Owned)
+	} else {
+		cell += " (released)"
+	}
+	if entry.Shiny {
+		cell += " ★"
+	}
+	return cell
+}
+
+// printCaughtGrid prints entries in as many columns as fit the terminal, filling
+// each column top to bottom so the order reads downwards.
+func printCaughtGrid(entries []caughtEntry) {
+	width := 80
+	if w, _, err := terminalSize(int(os.Stdout.Fd())); err == nil && w > 0 {
+		width = w
+	}
+	cells := make([]string, len(entries))
+	cellWidth := 0
+	for i, entry := range entries {
+		cells[i] = caughtCell(entry)
+		cellWidth = max(cellWidth, utf8.RuneCountInString(cells[i])+2)
+	}
+	columns := max(1, width/cellWidth)
+	rows := (len(cells) + columns - 1) / columns
+
+	for row := 0; row < rows; row++ {
+		for col := 0; col < columns; col++ {
+			i := col*rows + row
+			if i >= len(cells) {
+				break
+			}
+			text := cells[i] + strings.Repeat(" ", cellWidth-utf8.RuneCountInString(cells[i]))
+			if entries[i].Owned == 0 {
+				color.New(color.FgHiBlack).Print(text)
+			} else {
+				typeColor(firstType(entries[i])).Print(text)
+			}
+		}
+		fmt.Println()
+	}
+}
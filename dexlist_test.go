@@ -0,0 +1,71 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// testCaughtEntries are three caught species: a released bulbasaur, and a charmander
+// and shiny chikorita caught on different days.
+func testCaughtEntries() []caughtEntry {
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []caughtEntry{
+		{dexRow: dexRow{ID: 1, Name: "bulbasaur", Types: []string{"grass", "poison"}, Total: 318}, Species: 1},
+		{dexRow: dexRow{ID: 4, Name: "charmander", Types: []string{"fire"}, Total: 309, Owned: 2}, Species: 4, FirstCaught: day.AddDate(0, 0, 2)},
+		{dexRow: dexRow{ID: 152, Name: "chikorita", Types: []string{"grass"}, Total: 318, Owned: 1}, Species: 152, FirstCaught: day, Shiny: true},
+	}
+}
+
+func TestSortCaught(t *testing.T) {
+	cases := []struct {
+		by       string
+		expected []string
+	}{
+		{by: "id", expected: []string{"bulbasaur", "charmander", "chikorita"}},
+		{by: "name", expected: []string{"bulbasaur", "charmander", "chikorita"}},
+		{by: "type", expected: []string{"charmander", "bulbasaur", "chikorita"}},
+		{by: "bst", expected: []string{"bulbasaur", "chikorita", "charmander"}},
+		{by: "caught-at", expected: []string{"chikorita", "charmander", "bulbasaur"}},
+	}
+
+	for _, c := range cases {
+		entries := testCaughtEntries()
+		sortCaught(entries, c.by)
+		for i, entry := range entries {
+			if entry.Name != c.expected[i] {
+				t.Errorf("--sort %v: Expected %v, Got %v at %d", c.by, c.expected[i], entry.Name, i)
+			}
+		}
+	}
+}
+
+func TestFilterCaught(t *testing.T) {
+	johto, _ := parseGeneration("johto")
+	kanto, _ := parseGeneration("1")
+
+	if got := filterCaught(testCaughtEntries(), "grass", nil, false); len(got) != 2 {
+		t.Errorf("--type grass: Expected 2 entries, Got %d", len(got))
+	}
+	if got := filterCaught(testCaughtEntries(), "", &johto, false); len(got) != 1 || got[0].Name != "chikorita" {
+		t.Errorf("--gen johto: Expected chikorita only, Got %v", got)
+	}
+	if got := filterCaught(testCaughtEntries(), "grass", &kanto, false); len(got) != 1 || got[0].Name != "bulbasaur" {
+		t.Errorf("--type grass --gen 1: Expected bulbasaur only, Got %v", got)
+	}
+	if got := filterCaught(testCaughtEntries(), "", nil, true); len(got) != 1 || got[0].Name != "chikorita" {
+		t.Errorf("--shiny: Expected chikorita only, Got %v", got)
+	}
+	if _, ok := parseGeneration("10"); ok {
+		t.Errorf("Expected generation 10 to be unknown")
+	}
+}
+
+func TestCaughtCell(t *testing.T) {
+	entries := testCaughtEntries()
+	expected := []string{"#0001 bulbasaur (released)", "#0004 charmander x2", "#0152 chikorita x1 ★"}
+	for i, entry := range entries {
+		if actual := caughtCell(entry); actual != expected[i] {
+			t.Errorf("Expected %q, Got %q", expected[i], actual)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
+	"github.com/fatih/color"
+)
+
+// wildEncounter is a wild Pokémon that appeared in the current area.
+type wildEncounter struct {
+	Pokemon    string   // Species name, e.g. "pidgey"
+	Level      int      // Level rolled between the slot's min and max level
+	Method     string   // Encounter method that found it, e.g. "walk" or "old-rod"
+	Version    string   // Game version the encounter table belongs to
+	Conditions []string // Conditions attached to the slot, e.g. "time-night"
+	Shiny      bool     // Rolled when it appeared, at the game's shiny odds
+}
+
+// encounterSlot is one row of an area's encounter table for a method and version.
+type encounterSlot struct {
+	Pokemon    string
+	Chance     int
+	MinLevel   int
+	MaxLevel   int
+	Conditions []string
+}
+
+// encounterSlots collects every slot of an area matching the given method and version.
+func encounterSlots(areaDetails locationAreaDetails, method, version string) []encounterSlot {
+	slots := []encounterSlot{}
+	for _, encounter := range areaDetails.PokemonEncounters {
+		for _, versionDetail := range encounter.VersionDetails {
+			if versionDetail.Version.Name != version {
+				continue
+			}
+			for _, detail := range versionDetail.EncounterDetails {
+				if detail.Method.Name != method {
+					continue
+				}
+				conditions := []string{}
+				for _, condition := range detail.ConditionValues {
+					conditions = append(conditions, condition.Name)
+				}
+				slots = append(slots, encounterSlot{
+					Pokemon:    encounter.Pokemon.Name,
+					Chance:     detail.Chance,
+					MinLevel:   detail.MinLevel,
+					MaxLevel:   detail.MaxLevel,
+					Conditions: conditions,
+				})
+			}
+		}
+	}
+	return slots
+}
+
+// encounterVersions lists, in order of first appearance, the game versions
+// that have at least one slot for the given method in the area.
+func encounterVersions(areaDetails locationAreaDetails, method string) []string {
+	versions := []string{}
+	seen := make(map[string]bool)
+	for _, encounter := range areaDetails.PokemonEncounters {
+		for _, versionDetail := range encounter.VersionDetails {
+			name := versionDetail.Version.Name
+			if seen[name] {
+				continue
+			}
+			for _, detail := range versionDetail.EncounterDetails {
+				if detail.Method.Name == method {
+					seen[name] = true
+					versions = append(versions, name)
+					break
+				}
+			}
+		}
+	}
+	return versions
+}
+
+// rollEncounter picks a slot weighted by its chance and rolls a level within its range.
+// It returns false when there are no slots (or none with a positive chance).
+func rollEncounter(slots []encounterSlot, rng *rand.Rand) (encounterSlot, int, bool) {
+	total := 0
+	for _, slot := range slots {
+		if slot.Chance > 0 {
+			total += slot.Chance
+		}
+	}
+	if total == 0 {
+		return encounterSlot{}, 0, false
+	}
+
+	roll := rng.Intn(total)
+	for _, slot := range slots {
+		if slot.Chance <= 0 {
+			continue
+		}
+		if roll < slot.Chance {
+			level := slot.MinLevel
+			if slot.MaxLevel > slot.MinLevel {
+				level += rng.Intn(slot.MaxLevel - slot.MinLevel + 1)
+			}
+			return slot, level, true
+		}
+		roll -= slot.Chance
+	}
+	return encounterSlot{}, 0, false // Unreachable as long as total is consistent
+}
+
+// encounterRNG is the random source for wild encounters.
+var encounterRNG = rand.New(rand.NewSource(rand.Int63()))
+
+// searchForEncounter rolls a wild encounter in the current area with the given method
+// and remembers it on the config, so that catch without an argument can target it.
+func searchForEncounter(configPtr *config, cachePtr *internal.Cache, method string, args []string) error {
+	if busyInBattle(configPtr) {
+		return nil
+	}
+	if configPtr.CurrentArea == "" {
+		color.New(color.FgHiRed, color.Bold).Println("You are not in any area yet. Use travel <area> first.")
+		return nil
+	}
+	_, flags := parseFlags(args)
+
+	areaDetails, err := fetchLocationArea(cachePtr, configPtr.CurrentArea)
+	if err != nil {
+		return err
+	}
+
+	// Use the requested or selected game, or fall back to the first game with this kind of encounter here.
+	version := flags["game"]
+	if version != "" {
+		if known, err := knownVersion(cachePtr, version); err != nil || !known {
+			return err
+		}
+	} else {
+		version = configPtr.Version
+	}
+	if version == "" {
+		versions := encounterVersions(areaDetails, method)
+		if len(versions) == 0 {
+			color.New(color.FgHiBlack).Printf("Nothing can be found by %v in %v.\n", method, configPtr.CurrentArea)
+			return nil
+		}
+		version = versions[0]
+	}
+
+	slot, level, ok := rollEncounter(encounterSlots(areaDetails, method, version), encounterRNG)
+	if !ok {
+		color.New(color.FgHiBlack).Printf("Nothing can be found by %v in %v (%v version).\n", method, configPtr.CurrentArea, version)
+		return nil
+	}
+
+	configPtr.Encounter = &wildEncounter{
+		Pokemon:    slot.Pokemon,
+		Level:      level,
+		Method:     method,
+		Version:    version,
+		Conditions: slot.Conditions,
+		Shiny:      rollShiny(configPtr.shinyOdds(), encounterRNG),
+	}
+	configPtr.markSeen(slot.Pokemon)
+	configPtr.unsaved = true
+	if configPtr.Encounter.Shiny {
+		color.New(color.FgHiYellow, color.Bold).Printf("★ A shiny wild %v (Lv. %d) appeared! ★\n", slot.Pokemon, level)
+	} else {
+		color.New(color.FgHiMagenta, color.Bold).Printf("A wild %v (Lv. %d) appeared!\n", slot.Pokemon, level)
+	}
+	if len(slot.Conditions) > 0 {
+		color.New(color.FgHiBlack).Printf("  (only when: %v)\n", strings.Join(slot.Conditions, ", "))
+	}
+	color.New(color.FgCyan).Printf("Encounter rate: %d%% (%v version). Use catch to throw a Pokéball.\n", slot.Chance, version)
+	fmt.Println()
+	return nil
+}
+
+// walk looks for a wild Pokémon in the tall grass of the current area.
+func walk(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	return searchForEncounter(configPtr, cachePtr, "walk", args)
+}
+
+// surf looks for a wild Pokémon on the water of the current area.
+func surf(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	return searchForEncounter(configPtr, cachePtr, "surf", args)
+}
+
+// fish looks for a wild Pokémon with the given rod ("old", "good" or "super").
+func fish(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	positional, _ := parseFlags(args)
+	if len(positional) == 0 {
+		color.New(color.FgHiRed, color.Bold).Println("Error: missing rod argument (old, good or super).")
+		return nil
+	}
+
+	rod := strings.TrimSuffix(positional[0], "-rod")
+	switch rod {
+	case "old", "good", "super":
+	default:
+		color.New(color.FgHiRed, color.Bold).Printf("Unknown rod %v. Use old, good or super.\n", positional[0])
+		return nil
+	}
+	return searchForEncounter(configPtr, cachePtr, rod+"-rod", args)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+)
+
+// testArea is a trimmed location-area response with two games and two methods.
+const testArea = `{
+	"pokemon_encounters": [
+		{
+			"pokemon": {"name": "pidgey"},
+			"version_details": [
+				{"version": {"name": "red"}, "max_chance": 40, "encounter_details": [
+					{"chance": 30, "min_level": 2, "max_level": 4, "method": {"name": "walk"}, "condition_values": []},
+					{"chance": 10, "min_level": 5, "max_level": 5, "method": {"name": "walk"}, "condition_values": [{"name": "time-morning"}]}
+				]}
+			]
+		},
+		{
+			"pokemon": {"name": "magikarp"},
+			"version_details": [
+				{"version": {"name": "blue"}, "max_chance": 100, "encounter_details": [
+					{"chance": 100, "min_level": 5, "max_level": 5, "method": {"name": "old-rod"}, "condition_values": []}
+				]}
+			]
+		}
+	]
+}`
+
+// TestEncounterSlots checks that slots are filtered by method and version.
+func TestEncounterSlots(t *testing.T) {
+	var areaDetails locationAreaDetails
+	if err := json.Unmarshal([]byte(testArea), &areaDetails); err != nil {
+		t.Fatalf("could not parse test area: %v", err)
+	}
+
+	cases := []struct {
+		method   string
+		version  string
+		expected int // Number of slots expected
+	}{
+		{method: "walk", version: "red", expected: 2},
+		{method: "walk", version: "blue", expected: 0},
+		{method: "old-rod", version: "blue", expected: 1},
+	}
+
+	for _, c := range cases {
+		slots := encounterSlots(areaDetails, c.method, c.version)
+		if len(slots) != c.expected {
+			t.Errorf("%s/%s: Expected %v slots, Got %v", c.method, c.version, c.expected, len(slots))
+		}
+	}
+
+	versions := encounterVersions(areaDetails, "old-rod")
+	if len(versions) != 1 || versions[0] != "blue" {
+		t.Errorf("Expected old-rod versions [blue], Got %v", versions)
+	}
+}
+
+// TestRollEncounter checks that rolls follow the chance weights and stay within the level range.
+func TestRollEncounter(t *testing.T) {
+	slots := []encounterSlot{
+		{Pokemon: "rattata", Chance: 90, MinLevel: 2, MaxLevel: 4},
+		{Pokemon: "pikachu", Chance: 10, MinLevel: 3, MaxLevel: 3},
+		{Pokemon: "never", Chance: 0, MinLevel: 1, MaxLevel: 1},
+	}
+	rng := rand.New(rand.NewSource(1))
+
+	counts := make(map[string]int)
+	for i := 0; i < 10000; i++ {
+		slot, level, ok := rollEncounter(slots, rng)
+		if !ok {
+			t.Fatalf("expected an encounter")
+		}
+		if level < slot.MinLevel || level > slot.MaxLevel {
+			t.Errorf("level %v outside %v-%v for %v", level, slot.MinLevel, slot.MaxLevel, slot.Pokemon)
+		}
+		counts[slot.Pokemon]++
+	}
+
+	if counts["never"] != 0 {
+		t.Errorf("a slot with zero chance was rolled %v times", counts["never"])
+	}
+	// With 10000 rolls the 10% slot should land well within 8-12%.
+	if counts["pikachu"] < 800 || counts["pikachu"] > 1200 {
+		t.Errorf("Expected about 1000 pikachu, Got %v", counts["pikachu"])
+	}
+
+	if _, _, ok := rollEncounter(nil, rng); ok {
+		t.Errorf("expected no encounter without slots")
+	}
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
+	"github.com/fatih/color"
+)
+
+// evolutionDetail is one way a species can evolve into the next one in its chain.
+type evolutionDetail struct {
+	Trigger struct {
+		Name string `json:"name"` // "level-up", "use-item", "trade", ...
+		URL  string `json:"url"`
+	} `json:"trigger"`
+	MinLevel *int `json:"min_level"`
+	Item     *struct {
+		Name string `json:"name"` // Evolution item, e.g. "fire-stone"
+		URL  string `json:"url"`
+	} `json:"item"`
+	Gender    *int   `json:"gender"`      // 1 for female, 2 for male
+	TimeOfDay string `json:"time_of_day"` // "day", "night" or ""
+	KnownMove *struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"known_move"`
+
+	// Conditions the game doesn't simulate; an evolution needing any of them never triggers.
+	MinHappiness          *int `json:"min_happiness"`
+	MinAffection          *int `json:"min_affection"`
+	MinBeauty             *int `json:"min_beauty"`
+	RelativePhysicalStats *int `json:"relative_physical_stats"`
+	HeldItem              any  `json:"held_item"`
+	KnownMoveType         any  `json:"known_move_type"`
+	Location              any  `json:"location"`
+	PartySpecies          any  `json:"party_species"`
+	PartyType             any  `json:"party_type"`
+	TradeSpecies          any  `json:"trade_species"`
+	NeedsOverworldRain    bool `json:"needs_overworld_rain"`
+	TurnUpsideDown        bool `json:"turn_upside_down"`
+}
+
+// chainLink is a species in an evolution chain together with what it evolves into.
+type chainLink struct {
+	Species struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"species"`
+	EvolutionDetails []evolutionDetail `json:"evolution_details"` // How the previous link evolves into this one
+	EvolvesTo        []chainLink       `json:"evolves_to"`
+}
+
+// evolutionChain holds the data from the PokeAPI evolution-chain endpoint.
+type evolutionChain struct {
+	ID    int       `json:"id"`
+	Chain chainLink `json:"chain"`
+}
+
+// pendingEvolution is an evolution waiting for the trainer to confirm or cancel it.
+type pendingEvolution struct {
+	owned    *ownedPokemon
+	intoName string // Pokémon name of the evolved form, e.g. "charmeleon"
+}
+
+// evolutionTime is the clock used for time-of-day evolutions.
+var evolutionTime = time.Now
+
+// fetchEvolutionChain looks up the evolution chain a species belongs to.
+func fetchEvolutionChain(cachePtr *internal.Cache, species pokemonSpecies) (evolutionChain, error) {
+	var chain evolutionChain
+	val, err := fetchData(cachePtr, species.EvolutionChain.URL)
+	if err != nil {
+		return chain, err
+	}
+	err = json.Unmarshal(val, &chain)
+	return chain, err
+}
+
+// nextEvolutions returns the links a species can evolve into, or nil if it doesn't evolve.
+func nextEvolutions(link chainLink, speciesName string) []chainLink {
+	if link.Species.Name == speciesName {
+		return link.EvolvesTo
+	}
+	for _, next := range link.EvolvesTo {
+		if found := nextEvolutions(next, speciesName); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// simulated reports whether an evolution only depends on conditions this game tracks.
+func (detail evolutionDetail) simulated() bool {
+	return detail.MinHappiness == nil && detail.MinAffection == nil && detail.MinBeauty == nil &&
+		detail.RelativePhysicalStats == nil && detail.HeldItem == nil && detail.KnownMoveType == nil &&
+		detail.Location == nil && detail.PartySpecies == nil && detail.PartyType == nil &&
+		detail.TradeSpecies == nil && !detail.NeedsOverworldRain && !detail.TurnUpsideDown
+}
+
+// conditionsMet checks the level, gender, time of day and known move an evolution requires.
+func (detail evolutionDetail) conditionsMet(owned *ownedPokemon) bool {
+	if !detail.simulated() {
+		return false
+	}
+	if detail.MinLevel != nil && owned.Level < *detail.MinLevel {
+		return false
+	}
+	if detail.Gender != nil {
+		if (*detail.Gender == 1 && owned.Gender != "female") || (*detail.Gender == 2 && owned.Gender != "male") {
+			return false
+		}
+	}
+	if detail.TimeOfDay != "" {
+		hour := evolutionTime().Hour()
+		isDay := hour >= 6 && hour < 18
+		if (detail.TimeOfDay == "day" && !isDay) || (detail.TimeOfDay == "night" && isDay) {
+			return false
+		}
+	}
+	if detail.KnownMove != nil && !knowsMove(owned, detail.KnownMove.Name) {
+		return false
+	}
+	return true
+}
+
+// findEvolution returns the species an owned Pokémon evolves into with the given trigger
+// ("level-up" or "use-item"), or "" if none of its evolutions are ready.
+func findEvolution(chain evolutionChain, speciesName string, owned *ownedPokemon, trigger, item string) string {
+	for _, next := range nextEvolutions(chain.Chain, speciesName) {
+		for _, detail := range next.EvolutionDetails {
+			if detail.Trigger.Name != trigger {
+				continue
+			}
+			if trigger == "use-item" && (detail.Item == nil || detail.Item.Name != item) {
+				continue
+			}
+			if detail.conditionsMet(owned) {
+				return next.Species.Name
+			}
+		}
+	}
+	return ""
+}
+
+// evolvedPokemon returns the Pokémon an owned pokemonName of speciesName becomes when
+// it evolves into the species into. It keeps its form, so vulpix-alola becomes
+// ninetales-alola, unless into has no such variety.
+func evolvedPokemon(speciesName, pokemonName string, into pokemonSpecies) string {
+	if form := formName(speciesName, pokemonName); form != "" {
+		if name, ok := resolveForm(into, form); ok {
+			return name
+		}
+	}
+	return into.defaultPokemon()
+}
+
+// checkEvolution looks for an evolution of an owned Pokémon and, if one is ready,
+// asks the trainer to confirm it.
+func checkEvolution(configPtr *config, cachePtr *internal.Cache, owned *ownedPokemon, details pokemonDetails, trigger, item string) (bool, error) {
+	species, err := fetchSpecies(cachePtr, details.Species.Name)
+	if err != nil {
+		return false, err
+	}
+	chain, err := fetchEvolutionChain(cachePtr, species)
+	if err != nil {
+		return false, err
+	}
+
+	intoSpecies := findEvolution(chain, species.Name, owned, trigger, item)
+	if intoSpecies == "" {
+		return false, nil
+	}
+	into, err := fetchSpecies(cachePtr, intoSpecies)
+	if err != nil {
+		return false, err
+	}
+
+	intoName := evolvedPokemon(species.Name, owned.Species, into)
+	configPtr.Evolution = &pendingEvolution{owned: owned, intoName: intoName}
+	color.New(color.FgHiYellow, color.Bold).Printf("What? %v is evolving into %v! Let it evolve? (yes/no)\n", owned.displayName(), intoName)
+	return true, nil
+}
+
+// answerEvolution completes or cancels the pending evolution.
+func answerEvolution(configPtr *config, cachePtr *internal.Cache, pokedex map[string]pokemonDetails, evolve bool) error {
+	pending := configPtr.Evolution
+	configPtr.Evolution = nil
+	owned := pending.owned
+	if !evolve {
+		color.New(color.FgHiBlack).Printf("Huh? %v stopped evolving!\n", owned.displayName())
+		return nil
+	}
+
+	details, err := fetchPokemon(cachePtr, pending.intoName)
+	if err != nil {
+		return err
+	}
+	oldName := owned.displayName()
+	_, registered := pokedex[pending.intoName]
+
+	// Only the species changes; level, IVs, EVs, nature, moves and nickname stay.
+	owned.Species = pending.intoName
+	pokedex[pending.intoName] = details
+	configPtr.markSeen(pending.intoName)
+	configPtr.unsaved = true
+
+	color.New(color.FgHiGreen, color.Bold).Printf("Congratulations! Your %v evolved into %v!\n", oldName, pending.intoName)
+	if !registered {
+		color.New(color.FgCyan).Printf("%v was registered in your Pokedex.\n", pending.intoName)
+	}
+
+	// It may learn moves of its new form right away.
+	versionGroup := learnVersionGroup(details, configPtr.VersionGroup)
+	for _, move := range levelUpMoves(details, versionGroup, owned.Level-1, owned.Level) {
+		learnMove(owned, move)
+	}
+	return nil
+}
+
+// answerPrompt resolves a pending yes/no question with the user's input.
+// It returns false when the input isn't an answer, so the question is asked again.
+func answerPrompt(configPtr *config, cachePtr *internal.Cache, pokedex map[string]pokemonDetails, words []string) (bool, error) {
+	if len(words) != 1 {
+		return false, nil
+	}
+	switch words[0] {
+	case "y", "yes", "confirm":
+		return true, answerEvolution(configPtr, cachePtr, pokedex, true)
+	case "n", "no", "cancel":
+		return true, answerEvolution(configPtr, cachePtr, pokedex, false)
+	}
+	return false, nil
+}
+
+// use uses an item on an owned Pokémon: use <item> on <pokemon>. Evolution stones
+// (and other evolution items) make the Pokémon evolve if its species can.
+func use(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	if len(args) == 3 && args[1] == "on" {
+		args = []string{args[0], args[2]}
+	}
+	if len(args) != 2 {
+		color.New(color.FgHiRed, color.Bold).Println("Error: usage is use <item> on <pokemon>.")
+		return nil
+	}
+	if busyInBattle(configPtr) {
+		return nil
+	}
+	item := args[0]
+	owned, ok := lookupOwned(configPtr, args[1])
+	if !ok {
+		return nil
+	}
+
+	color.New(color.Bold).Printf("You used the %v on %v.\n", item, owned.displayName())
+	evolving, err := checkEvolution(configPtr, cachePtr, owned, pokedex[owned.Species], "use-item", item)
+	if err != nil {
+		return err
+	}
+	if !evolving {
+		color.New(color.FgHiBlack).Println("It won't have any effect.")
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// testChain is a trimmed eevee evolution chain with item, happiness and time-of-day evolutions.
+const testChain = `{
+	"id": 67,
+	"chain": {
+		"species": {"name": "eevee"},
+		"evolution_details": [],
+		"evolves_to": [
+			{"species": {"name": "vaporeon"}, "evolves_to": [], "evolution_details": [
+				{"trigger": {"name": "use-item"}, "item": {"name": "water-stone"}, "min_level": null}
+			]},
+			{"species": {"name": "umbreon"}, "evolves_to": [], "evolution_details": [
+				{"trigger": {"name": "level-up"}, "min_happiness": 160, "time_of_day": "night"}
+			]},
+			{"species": {"name": "testeon"}, "evolves_to": [], "evolution_details": [
+				{"trigger": {"name": "level-up"}, "min_level": 20, "gender": 1}
+			]}
+		]
+	}
+}`
+
+// TestFindEvolution checks which evolutions trigger for items, levels and conditions.
+func TestFindEvolution(t *testing.T) {
+	var chain evolutionChain
+	if err := json.Unmarshal([]byte(testChain), &chain); err != nil {
+		t.Fatalf("could not parse test chain: %v", err)
+	}
+
+	cases := []struct {
+		name     string
+		owned    *ownedPokemon
+		trigger  string
+		item     string
+		expected string
+	}{
+		{name: "stone", owned: &ownedPokemon{Level: 5, Gender: "male"}, trigger: "use-item", item: "water-stone", expected: "vaporeon"},
+		{name: "wrong stone", owned: &ownedPokemon{Level: 5, Gender: "male"}, trigger: "use-item", item: "fire-stone", expected: ""},
+		{name: "too low", owned: &ownedPokemon{Level: 19, Gender: "female"}, trigger: "level-up", expected: ""},
+		{name: "level and gender", owned: &ownedPokemon{Level: 20, Gender: "female"}, trigger: "level-up", expected: "testeon"},
+		// Happiness isn't simulated, and neither is this made-up male evolution
+		{name: "wrong gender", owned: &ownedPokemon{Level: 30, Gender: "male"}, trigger: "level-up", expected: ""},
+	}
+
+	for _, c := range cases {
+		if actual := findEvolution(chain, "eevee", c.owned, c.trigger, c.item); actual != c.expected {
+			t.Errorf("%v: Expected %q, Got %q", c.name, c.expected, actual)
+		}
+	}
+
+	if evolutions := nextEvolutions(chain.Chain, "vaporeon"); len(evolutions) != 0 {
+		t.Errorf("Expected vaporeon not to evolve, Got %v", evolutions)
+	}
+}
+
+// TestEvolvedPokemon checks that a regional form evolves into the same form.
+func TestEvolvedPokemon(t *testing.T) {
+	var ninetales pokemonSpecies
+	if err := json.Unmarshal([]byte(`{"name": "ninetales", "varieties": [
+		{"is_default": true, "pokemon": {"name": "ninetales"}},
+		{"is_default": false, "pokemon": {"name": "ninetales-alola"}}
+	]}`), &ninetales); err != nil {
+		t.Fatalf("could not parse test species: %v", err)
+	}
+
+	cases := []struct {
+		pokemon  string
+		expected string
+	}{
+		{pokemon: "vulpix-alola", expected: "ninetales-alola"},
+		{pokemon: "vulpix", expected: "ninetales"},
+		// No Galarian ninetales, so it becomes the default one
+		{pokemon: "vulpix-galar", expected: "ninetales"},
+	}
+	for _, c := range cases {
+		if actual := evolvedPokemon("vulpix", c.pokemon, ninetales); actual != c.expected {
+			t.Errorf("%v: Expected %v, Got %v", c.pokemon, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
+	"github.com/fatih/color"
+)
+
+// exportFormats lists the formats export can write, with the writer of each.
+var exportFormats = map[string]func(w io.Writer, rows []dexRow) error{
+	"csv":      writeDexCSV,
+	"json":     writeDexJSON,
+	"markdown": writeDexMarkdown,
+	"md":       writeDexMarkdown,
+	"html":     writeDexHTML,
+}
+
+// dexRow is one caught species in an export.
+type dexRow struct {
+	ID     int            `json:"id"`
+	Name   string         `json:"name"`
+	Types  []string       `json:"types"`
+	Stats  map[string]int `json:"stats"` // Base stats by PokeAPI stat name
+	Total  int            `json:"total"`
+	Height float64        `json:"height_m"`
+	Weight float64        `json:"weight_kg"`
+	Owned  int            `json:"owned"` // How many of the species the trainer still owns
+	Sprite string         `json:"sprite"`
+	Image  template.URL   `json:"-"` // The sprite embedded as a data URL, for HTML
+}
+
+// dexRows builds the export rows of every caught species, in national dex order.
+func dexRows(configPtr *config, pokedex map[string]pokemonDetails) []dexRow {
+	rows := []dexRow{}
+	for name, details := range pokedex {
+		row := dexRow{
+			ID:     details.ID,
+			Name:   name,
+			Types:  []string{},
+			Stats:  make(map[string]int),
+			Height: float64(details.Height) / 10,
+			Weight: float64(details.Weight) / 10,
+			Owned:  len(ownedOfSpecies(configPtr, name)),
+			Sprite: details.Sprites.FrontDefault,
+		}
+		for _, t := range details.Types {
+			row.Types = append(row.Types, t.Type.Name)
+		}
+		for _, value := range details.Stats {
+			row.Stats[value.Stat.Name] = value.BaseStat
+			row.Total += value.BaseStat
+		}
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].ID != rows[j].ID {
+			return rows[i].ID < rows[j].ID
+		}
+		return rows[i].Name < rows[j].Name
+	})
+	return rows
+}
+
+// dexHeaders are the columns of the CSV and Markdown exports.
+func dexHeaders() []string {
+	headers := []string{"id", "name", "types"}
+	headers = append(headers, internal.StatNames...)
+	return append(headers, "total", "height_m", "weight_kg", "owned")
+}
+
+// dexCells returns a row's values in the order of dexHeaders.
+func dexCells(row dexRow) []string {
+	cells := []string{strconv.Itoa(row.ID), row.Name, strings.Join(row.Types, "/")}
+	for _, stat := range internal.StatNames {
+		cells = append(cells, strconv.Itoa(row.Stats[stat]))
+	}
+	return append(cells,
+		strconv.Itoa(row.Total),
+		strconv.FormatFloat(row.Height, 'f', 1, 64),
+		strconv.FormatFloat(row.Weight, 'f', 1, 64),
+		strconv.Itoa(row.Owned),
+	)
+}
+
+// writeDexCSV writes the rows as CSV with a header line.
+func writeDexCSV(w io.Writer, rows []dexRow) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(dexHeaders()); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := writer.Write(dexCells(row)); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeDexJSON writes the rows as an indented JSON array.
+func writeDexJSON(w io.Writer, rows []dexRow) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(rows)
+}
+
+// writeDexMarkdown writes the rows as a Markdown table.
+func writeDexMarkdown(w io.Writer, rows []dexRow) error {
+	headers := dexHeaders()
+	separators := make([]string, len(headers))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	lines := []string{
+		"# Pokédex",
+		"",
+		fmt.Sprintf("%d species caught.", len(rows)),
+		"",
+		"| " + strings.Join(headers, " | ") + " |",
+		"| " + strings.Join(separators, " | ") + " |",
+	}
+	for _, row := range rows {
+		cells := dexCells(row)
+		for i, cell := range cells {
+			cells[i] = strings.ReplaceAll(cell, "|", `\|`)
+		}
+		lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+	}
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+// dexHTML is the page of the HTML export. It has no outside dependencies
+// except sprites that couldn't be embedded.
+var dexHTML = template.Must(template.New("pokedex").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Pokédex</title>
+<style>
+body { font-family: sans-serif; background: #f4f4f4; color: #222; margin: 2em; }
+h1 { color: #cc0000; }
+.grid { display: flex; flex-wrap: wrap; gap: 1em; }
+.card { background: #fff; border-radius: 8px; box-shadow: 0 1px 4px #0003; padding: 1em; width: 14em; }
+.card img { image-rendering: pixelated; width: 96px; height: 96px; display: block; margin: 0 auto; }
+.card h2 { margin: 0.2em 0; font-size: 1.1em; text-transform: capitalize; }
+.id { color: #888; }
+.type { display: inline-block; background: #555; color: #fff; border-radius: 4px; padding: 0 0.4em; margin-right: 0.2em; font-size: 0.85em; }
+table { width: 100%; font-size: 0.85em; border-collapse: collapse; }
+td:last-child { text-align: right; }
+</style>
+</head>
+<body>
+<h1>Pokédex</h1>
+<p>{{len .}} species caught.</p>
+<div class="grid">
+{{range .}}<div class="card">
+{{if .Image}}<img src="{{.Image}}" alt="{{.Name}}">{{end}}
+<h2><span class="id">#{{.ID}}</span> {{.Name}}</h2>
+<div>{{range .Types}}<span class="type">{{.}}</span>{{end}}</div>
+<table>
+<tr><td>HP</td><td>{{index .Stats "hp"}}</td></tr>
+<tr><td>Attack</td><td>{{index .Stats "attack"}}</td></tr>
+<tr><td>Defense</td><td>{{index .Stats "defense"}}</td></tr>
+<tr><td>Sp. Atk</td><td>{{index .Stats "special-attack"}}</td></tr>
+<tr><td>Sp. Def</td><td>{{index .Stats "special-defense"}}</td></tr>
+<tr><td>Speed</td><td>{{index .Stats "speed"}}</td></tr>
+<tr><td><b>Total</b></td><td><b>{{.Total}}</b></td></tr>
+<tr><td>Height / weight</td><td>{{.Height}} m / {{.Weight}} kg</td></tr>
+<tr><td>Owned</td><td>{{.Owned}}</td></tr>
+</table>
+</div>
+{{end}}</div>
+</body>
+</html>
+`))
+
+// writeDexHTML writes the rows as a self-contained HTML page.
+func writeDexHTML(w io.Writer, rows []dexRow) error {
+	return dexHTML.Execute(w, rows)
+}
+
+// imageType returns the media type of image data. Most sprites are PNGs, but the
+// animated ones are GIFs and the dream world ones SVGs, which sniff as XML.
+func imageType(data []byte) string {
+	mediaType := http.DetectContentType(data)
+	if !strings.HasPrefix(mediaType, "image/") && bytes.Contains(data, []byte("<svg")) {
+		return "image/svg+xml"
+	}
+	return mediaType
+}
+
+// embedSprites fetches each row's sprite through the cache and embeds it as a data URL,
+// so the HTML page works offline. Sprites that can't be fetched link to the PokeAPI instead.
+func embedSprites(cachePtr *internal.Cache, rows []dexRow) {
+	for i := range rows {
+		if rows[i].Sprite == "" {
+			continue
+		}
+		data, err := fetchData(cachePtr, rows[i].Sprite)
+		if err != nil {
+			rows[i].Image = template.URL(rows[i].Sprite)
+			continue
+		}
+		rows[i].Image = template.URL("data:" + imageType(data) + ";base64," + base64.StdEncoding.EncodeToString(data))
+	}
+}
+
+// export writes the caught Pokémon to a file for use elsewhere: export csv dex.csv,
+// export json dex.json, export markdown dex.md or export html dex.html.
+func export(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	if len(args) < 2 {
+		color.New(color.FgHiRed, color.Bold).Println("Usage: export <csv|json|markdown|html> <path>")
+		return nil
+	}
+	write, ok := exportFormats[args[0]]
+	if !ok {
+		color.New(color.FgHiRed, color.Bold).Printf("Unknown format %v. Use csv, json, markdown or html.\n", args[0])
+		return nil
+	}
+	if len(pokedex) == 0 {
+		color.New(color.FgHiMagenta, color.Bold).Println("Your Pokedex is empty; there is nothing to export.")
+		return nil
+	}
+
+	rows := dexRows(configPtr, pokedex)
+	if args[0] == "html" {
+		embedSprites(cachePtr, rows)
+	}
+
+	// Like writeSave, write to a temporary file first so that a failed export
+	// never leaves an existing file half-written.
+	path := originalWord(configPtr.typed, args[1])
+	tmpPath := path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	if err := write(file, rows); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	color.New(color.FgHiGreen, color.Bold).Printf("Exported %d species to %v.\n", len(rows), path)
+	return nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
+)
+
+// testDexRows are two caught species, out of dex order.
+func testDexRows() []dexRow {
+	stats := map[string]int{"hp": 35, "attack": 55, "defense": 40, "special-attack": 50, "special-defense": 50, "speed": 90}
+	return []dexRow{
+		{ID: 122, Name: "mr-mime", Types: []string{"psychic", "fairy"}, Stats: stats, Total: 320, Height: 1.3, Weight: 54.5},
+		{ID: 25, Name: "pikachu", Types: []string{"electric"}, Stats: stats, Total: 320, Height: 0.4, Weight: 6, Owned: 2},
+	}
+}
+
+// TestDexRowsOrder checks that exports follow the national dex order.
+func TestDexRowsOrder(t *testing.T) {
+	pokedex := map[string]pokemonDetails{
+		"mr-mime":   {ID: 122, Name: "mr-mime"},
+		"pikachu":   {ID: 25, Name: "pikachu"},
+		"bulbasaur": {ID: 1, Name: "bulbasaur"},
+	}
+	rows := dexRows(&config{}, pokedex)
+	expected := []string{"bulbasaur", "pikachu", "mr-mime"}
+	for i, name := range expected {
+		if rows[i].Name != name {
+			t.Errorf("row %d: Expected %v, Got %v", i, name, rows[i].Name)
+		}
+	}
+}
+
+// TestExportFormats checks the CSV, Markdown, JSON and HTML output of the same rows.
+func TestExportFormats(t *testing.T) {
+	cases := []struct {
+		format   string
+		expected []string
+	}{
+		{format: "csv", expected: []string{
+			"id,name,types,hp,attack,defense,special-attack,special-defense,speed,total,height_m,weight_kg,owned",
+			"25,pikachu,electric,35,55,40,50,50,90,320,0.4,6.0,2",
+		}},
+		{format: "markdown", expected: []string{
+			"| id | name | types |",
+			"| 122 | mr-mime | psychic/fairy | 35 |",
+		}},
+		{format: "json", expected: []string{`"name": "pikachu"`, `"height_m": 0.4`}},
+		{format: "html", expected: []string{"<!DOCTYPE html>", "#25</span> pikachu", `<span class="type">fairy</span>`}},
+	}
+	for _, c := range cases {
+		var b bytes.Buffer
+		if err := exportFormats[c.format](&b, testDexRows()); err != nil {
+			t.Fatalf("%v: %v", c.format, err)
+		}
+		for _, text := range c.expected {
+			if !strings.Contains(b.String(), text) {
+				t.Errorf("%v: Expected output to contain %q, Got:\n%v", c.format, text, b.String())
+			}
+		}
+	}
+}
+
+// TestEmbedSprites checks that sprites are embedded with their own media type.
+func TestEmbedSprites(t *testing.T) {
+	cachePtr := internal.NewCache(time.Minute)
+	cachePtr.Add("https://example.com/a.png", []byte("\x89PNG\r\n\x1a\n"))
+	cachePtr.Add("https://example.com/b.gif", []byte("GIF89a"))
+	cachePtr.Add("https://example.com/c.svg", []byte(`<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg"></svg>`))
+	rows := []dexRow{
+		{Sprite: "https://example.com/a.png"},
+		{Sprite: "https://example.com/b.gif"},
+		{Sprite: "https://example.com/c.svg"},
+	}
+	embedSprites(cachePtr, rows)
+
+	expected := []string{"data:image/png;base64,", "data:image/gif;base64,", "data:image/svg+xml;base64,"}
+	for i, prefix := range expected {
+		if !strings.HasPrefix(string(rows[i].Image), prefix) {
+			t.Errorf("%v: Expected %v..., Got %.40v", rows[i].Sprite, prefix, rows[i].Image)
+		}
+	}
+}
+
+// TestExportReplacesFile checks that export replaces a file whole and leaves nothing behind,
+// and that a failed export keeps the old file.
+func TestExportReplacesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dex.csv")
+	if err := os.WriteFile(path, []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	pokedex := map[string]pokemonDetails{"pikachu": {ID: 25, Name: "pikachu"}}
+	if err := export(&config{}, nil, []string{"csv", path}, pokedex); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "pikachu") {
+		t.Errorf("Expected the export to replace the file, Got %q", data)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("Expected no temporary file left, Got %v", err)
+	}
+
+	// A directory in the way of the temporary file makes the export fail.
+	if err := os.WriteFile(path, []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(path+".tmp", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := export(&config{}, nil, []string{"csv", path}, pokedex); err == nil {
+		t.Errorf("Expected the export to fail")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "old" {
+		t.Errorf("Expected the old file to stay, Got %q", data)
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
+	"github.com/fatih/color"
+)
+
+// formName returns the form part of a variety's Pokémon name, e.g. "alola" for
+// "vulpix-alola" of vulpix, or "" for a name without one.
+func formName(speciesName, pokemonName string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(pokemonName, speciesName), "-")
+}
+
+// resolveForm returns the Pokémon name of a species' variety by its form, e.g.
+// "vulpix-alola" for alola. "" and "default" give the default variety.
+func resolveForm(species pokemonSpecies, form string) (string, bool) {
+	if form == "" || form == "default" {
+		return species.defaultPokemon(), true
+	}
+	for _, variety := range species.Varieties {
+		name := variety.Pokemon.Name
+		if name == form || formName(species.Name, name) == form {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// varietyForms lists the forms of a species' varieties, for messages.
+func varietyForms(species pokemonSpecies) []string {
+	forms := []string{}
+	for _, variety := range species.Varieties {
+		if variety.IsDefault {
+			forms = append(forms, "default")
+		} else {
+			forms = append(forms, formName(species.Name, variety.Pokemon.Name))
+		}
+	}
+	return forms
+}
+
+// lookupSpecies fetches the species of a species or Pokémon name,
+// so forms vulpix-alola finds the same varieties as forms vulpix.
+func lookupSpecies(cachePtr *internal.Cache, name string) (pokemonSpecies, error) {
+	species, err := fetchSpecies(cachePtr, name)
+	if err == nil {
+		return species, nil
+	}
+	pokemon, pokemonErr := fetchPokemon(cachePtr, name)
+	if pokemonErr != nil {
+		return species, err
+	}
+	return fetchSpecies(cachePtr, pokemon.Species.Name)
+}
+
+// caughtForms returns the caught Pokémon names that are forms of speciesName, sorted.
+func caughtForms(pokedex map[string]pokemonDetails, speciesName string) []string {
+	forms := []string{}
+	for _, name := range sortedPokedexNames(pokedex) {
+		if pokedex[name].Species.Name == speciesName {
+			forms = append(forms, name)
+		}
+	}
+	return forms
+}
+
+// forms lists the varieties of a species, such as regional variants and alternate forms,
+// with their types and which of them have been caught.
+func forms(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	if len(args) == 0 {
+		color.New(color.FgHiRed, color.Bold).Println("Error: missing species argument.")
+		return nil
+	}
+	species, err := lookupSpecies(cachePtr, args[0])
+	if err != nil {
+		return reportUnknown(configPtr, cachePtr, err, "pokemon", args[0])
+	}
+
+	labels := varietyForms(species)
+	rows := [][]string{}
+	for i, variety := range species.Varieties {
+		name := variety.Pokemon.Name
+		details, ok := pokedex[name]
+		if !ok {
+			details, err = fetchPokemon(cachePtr, name)
+			if err != nil {
+				return err
+			}
+		}
+		types := []string{}
+		for _, t := range details.Types {
+			types = append(types, t.Type.Name)
+		}
+		caught := ""
+		if ok {
+			caught = "✓"
+		}
+		rows = append(rows, []string{labels[i], name, strings.Join(types, "/"), caught})
+	}
+
+	color.New(color.FgCyan, color.Bold).Printf("Forms of %v (%d):\n", species.Name, len(rows))
+	printTable([]string{"Form", "Pokémon", "Types", "Caught"}, rows, func(row, col int) *color.Color {
+		if rows[row][3] != "" {
+			return color.New(color.FgHiGreen, color.Bold)
+		}
+		return nil
+	})
+	if len(rows) > 1 {
+		color.New(color.FgHiBlack).Printf("Catch one with catch %v --form <form>.\n", species.Name)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// testVulpixSpecies is the varieties part of vulpix, as the PokeAPI returns it.
+const testVulpixSpecies = `{"name": "vulpix", "varieties": [
+	{"is_default": true, "pokemon": {"name": "vulpix"}},
+	{"is_default": false, "pokemon": {"name": "vulpix-alola"}}
+]}`
+
+func TestResolveForm(t *testing.T) {
+	var vulpix pokemonSpecies
+	if err := json.Unmarshal([]byte(testVulpixSpecies), &vulpix); err != nil {
+		t.Fatalf("could not parse test species: %v", err)
+	}
+
+	cases := []struct {
+		form     string
+		expected string
+		found    bool
+	}{
+		{form: "alola", expected: "vulpix-alola", found: true},
+		{form: "vulpix-alola", expected: "vulpix-alola", found: true},
+		{form: "default", expected: "vulpix", found: true},
+		{form: "", expected: "vulpix", found: true},
+		{form: "galar", expected: "", found: false},
+	}
+	for _, c := range cases {
+		actual, found := resolveForm(vulpix, c.form)
+		if actual != c.expected || found != c.found {
+			t.Errorf("resolveForm(vulpix, %q) Expected %v %v, Got %v %v", c.form, c.expected, c.found, actual, found)
+		}
+	}
+
+	forms := varietyForms(vulpix)
+	if len(forms) != 2 || forms[0] != "default" || forms[1] != "alola" {
+		t.Errorf("Expected [default alola], Got %v", forms)
+	}
+}
+
+func TestCaughtForms(t *testing.T) {
+	var alola, meowth pokemonDetails
+	alola.Species.Name = "vulpix"
+	meowth.Species.Name = "meowth"
+	pokedex := map[string]pokemonDetails{"vulpix-alola": alola, "meowth-galar": meowth}
+
+	caught := caughtForms(pokedex, "vulpix")
+	if len(caught) != 1 || caught[0] != "vulpix-alola" {
+		t.Errorf("Expected [vulpix-alola], Got %v", caught)
+	}
+	if caught := caughtForms(pokedex, "pikachu"); len(caught) != 0 {
+		t.Errorf("Expected no forms of pikachu, Got %v", caught)
+	}
+}
+
+// TestSortCaughtForms checks that a form is listed with its species, not after every other species.
+func TestSortCaughtForms(t *testing.T) {
+	entries := []caughtEntry{
+		{dexRow: dexRow{ID: 38, Name: "ninetales"}, Species: 38},
+		{dexRow: dexRow{ID: 10103, Name: "vulpix-alola"}, Species: 37},
+		{dexRow: dexRow{ID: 37, Name: "vulpix"}, Species: 37},
+	}
+	sortCaught(entries, "id")
+	expected := []string{"vulpix", "vulpix-alola", "ninetales"}
+	for i, entry := range entries {
+		if entry.Name != expected[i] {
+			t.Errorf("Expected %v, Got %v at %d", expected[i], entry.Name, i)
+		}
+	}
+}
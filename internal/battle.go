@@ -0,0 +1,58 @@
+package internal
+
+// CritMultiplier is the damage bonus of a critical hit (generation VI onward).
+const CritMultiplier = 1.5
+
+// CritChance is the base chance of landing a critical hit (1 in 24).
+const CritChance = 1.0 / 24
+
+// STABMultiplier is the bonus for using a move that shares a type with its user.
+const STABMultiplier = 1.5
+
+// Damage computes the damage of one hit with the standard damage formula:
+//
+//	((2*level/5 + 2) * power * attack/defense) / 50 + 2
+//
+// which is then multiplied by STAB, type effectiveness, a critical hit
+// and a random factor between 0.85 and 1.0. A hit that isn't immune always does at least 1 damage.
+func Damage(level, power, attack, defense int, stab bool, effectiveness float64, crit bool, random float64) int {
+	if power <= 0 || effectiveness == 0 {
+		return 0
+	}
+	if defense < 1 {
+		defense = 1
+	}
+
+	base := float64((2*level/5+2)*power*attack/defense)/50 + 2
+	modifier := effectiveness * random
+	if stab {
+		modifier *= STABMultiplier
+	}
+	if crit {
+		modifier *= CritMultiplier
+	}
+
+	damage := int(base * modifier)
+	if damage < 1 {
+		damage = 1
+	}
+	return damage
+}
+
+// CatchChance returns the probability of catching a wild Pokémon with a regular Poké Ball,
+// using the generation III-IV catch rate formula: the lower its HP, the easier the catch.
+// captureRate is the species' capture rate from 3 (legendaries) to 255 (common Pokémon).
+func CatchChance(maxHP, currentHP, captureRate int) float64 {
+	if maxHP < 1 {
+		return 0
+	}
+	if currentHP < 1 {
+		currentHP = 1
+	}
+	a := float64((3*maxHP-2*currentHP)*captureRate) / float64(3*maxHP)
+	chance := a / 255
+	if chance > 1 {
+		return 1
+	}
+	return chance
+}
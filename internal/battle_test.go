@@ -0,0 +1,54 @@
+package internal
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestDamage checks the damage formula and its modifiers.
+func TestDamage(t *testing.T) {
+	cases := []struct {
+		level         int
+		power         int
+		attack        int
+		defense       int
+		stab          bool
+		effectiveness float64
+		crit          bool
+		random        float64
+		expected      int
+	}{
+		// Lv. 50, 80 power, 100 vs 100: ((22*80*100/100)/50)+2 = 37.2 -> 37
+		{level: 50, power: 80, attack: 100, defense: 100, effectiveness: 1, random: 1, expected: 37},
+		{level: 50, power: 80, attack: 100, defense: 100, stab: true, effectiveness: 1, random: 1, expected: 55},
+		{level: 50, power: 80, attack: 100, defense: 100, effectiveness: 2, random: 1, expected: 74},
+		{level: 50, power: 80, attack: 100, defense: 100, effectiveness: 1, crit: true, random: 1, expected: 55},
+		{level: 50, power: 80, attack: 100, defense: 100, effectiveness: 0, random: 1, expected: 0},
+		// Weak hits still do at least 1 damage
+		{level: 1, power: 10, attack: 5, defense: 200, effectiveness: 0.25, random: 0.85, expected: 1},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			actual := Damage(c.level, c.power, c.attack, c.defense, c.stab, c.effectiveness, c.crit, c.random)
+			if actual != c.expected {
+				t.Errorf("Expected damage: %v, Got: %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+// TestCatchChance checks that lower HP makes a catch more likely.
+func TestCatchChance(t *testing.T) {
+	full := CatchChance(100, 100, 45)
+	low := CatchChance(100, 1, 45)
+	if full >= low {
+		t.Errorf("Expected a weakened Pokémon to be easier to catch: full %v, low %v", full, low)
+	}
+	if chance := CatchChance(100, 1, 255); chance < 0.99 {
+		t.Errorf("Expected a near-certain catch, Got %v", chance)
+	}
+	if chance := CatchChance(0, 0, 45); chance != 0 {
+		t.Errorf("Expected 0 for invalid HP, Got %v", chance)
+	}
+}
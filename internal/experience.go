@@ -0,0 +1,43 @@
+package internal
+
+// MaxEV is the most effort values a single stat can hold.
+const MaxEV = 252
+
+// MaxTotalEVs is the most effort values a Pokémon can hold across all stats.
+const MaxTotalEVs = 510
+
+// MaxLevel is the highest level a Pokémon can reach.
+const MaxLevel = 100
+
+// ExperienceGain returns the experience a Pokémon earns for defeating a wild Pokémon,
+// using the generation I-IV formula base_experience * level / 7.
+func ExperienceGain(baseExperience, defeatedLevel int) int {
+	gain := baseExperience * defeatedLevel / 7
+	if gain < 1 {
+		return 1
+	}
+	return gain
+}
+
+// AddEVs adds effort values to evs in place, respecting the per-stat and total limits.
+func AddEVs(evs map[string]int, gained map[string]int) {
+	total := 0
+	for _, value := range evs {
+		total += value
+	}
+
+	for _, stat := range StatNames {
+		amount := gained[stat]
+		if amount > MaxEV-evs[stat] {
+			amount = MaxEV - evs[stat]
+		}
+		if amount > MaxTotalEVs-total {
+			amount = MaxTotalEVs - total
+		}
+		if amount <= 0 {
+			continue
+		}
+		evs[stat] += amount
+		total += amount
+	}
+}
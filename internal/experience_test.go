@@ -0,0 +1,30 @@
+package internal
+
+import (
+	"testing"
+)
+
+// TestExperienceGain checks the experience formula, including the minimum of 1.
+func TestExperienceGain(t *testing.T) {
+	if gain := ExperienceGain(64, 5); gain != 45 {
+		t.Errorf("Expected 45 experience, Got %v", gain)
+	}
+	if gain := ExperienceGain(0, 1); gain != 1 {
+		t.Errorf("Expected at least 1 experience, Got %v", gain)
+	}
+}
+
+// TestAddEVs checks that effort values stop at the per-stat and total limits.
+func TestAddEVs(t *testing.T) {
+	evs := map[string]int{"hp": 250, "attack": 0, "speed": 0}
+	AddEVs(evs, map[string]int{"hp": 3, "attack": 2})
+	if evs["hp"] != MaxEV || evs["attack"] != 2 {
+		t.Errorf("Expected hp %v and attack 2, Got %v", MaxEV, evs)
+	}
+
+	full := map[string]int{"hp": 252, "attack": 252, "defense": 6}
+	AddEVs(full, map[string]int{"speed": 3})
+	if full["speed"] != 0 {
+		t.Errorf("Expected no speed EVs past the total limit, Got %v", full["speed"])
+	}
+}
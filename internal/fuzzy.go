@@ -0,0 +1,127 @@
+package internal
+
+import (
+	"sort"
+	"strings"
+)
+
+// Match is a name found by FuzzyMatch. Lower scores are closer matches.
+type Match struct {
+	Name  string
+	Score int
+}
+
+// Distance returns the edit distance between a and b, counting insertions,
+// deletions, substitutions and swaps of neighbouring letters as one edit each.
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	// d[i][j] is the distance between the first i runes of a and the first j runes of b.
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
+
+// NormalizeName turns user input into PokeAPI name form, e.g. "Mr Mime" into "mr-mime".
+func NormalizeName(query string) string {
+	query = strings.ToLower(strings.TrimSpace(query))
+	query = strings.NewReplacer("_", " ", ".", "", "'", "").Replace(query)
+	return strings.Join(strings.Fields(query), "-")
+}
+
+// typoTolerance returns how many edits a query of the given length may contain.
+// Very short queries get none, otherwise they would match almost anything.
+func typoTolerance(length int) int {
+	switch {
+	case length < 4:
+		return 0
+	case length < 8:
+		return 1
+	case length < 12:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// matchScore returns how well name matches query, and whether it matches at all.
+// Exact matches come first, then prefixes, then names containing the query,
+// then names within a few typos of it.
+func matchScore(query, name string) (int, bool) {
+	compactQuery := strings.ReplaceAll(query, "-", "")
+	compactName := strings.ReplaceAll(name, "-", "")
+	switch {
+	case name == query || compactName == compactQuery:
+		return 0, true
+	case strings.HasPrefix(name, query):
+		return 1, true
+	case strings.Contains(name, query) || strings.Contains(compactName, compactQuery):
+		return 2, true
+	}
+
+	tolerance := typoTolerance(len(compactQuery))
+	if tolerance == 0 {
+		return 0, false
+	}
+	best := Distance(query, name)
+	// A typo in one word of a longer name, e.g. "pewter" in "pewtr-city"
+	for _, part := range strings.Split(name, "-") {
+		best = min(best, Distance(query, part))
+	}
+	// A typo in a name that isn't typed out in full yet
+	if len(name) > len(query) {
+		best = min(best, Distance(query, name[:len(query)])+1)
+	}
+	if best > tolerance {
+		return 0, false
+	}
+	return 3 + best, true
+}
+
+// FuzzyMatch returns the names matching query, best first, at most limit of them.
+// Ties go to the shorter name, then alphabetically.
+func FuzzyMatch(query string, names []string, limit int) []Match {
+	query = NormalizeName(query)
+	matches := []Match{}
+	if query == "" {
+		return matches
+	}
+	for _, name := range names {
+		if score, ok := matchScore(query, name); ok {
+			matches = append(matches, Match{Name: name, Score: score})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Score != b.Score {
+			return a.Score < b.Score
+		}
+		if len(a.Name) != len(b.Name) {
+			return len(a.Name) < len(b.Name)
+		}
+		return a.Name < b.Name
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"testing"
+)
+
+// TestDistance checks edits, including swapped neighbouring letters.
+func TestDistance(t *testing.T) {
+	cases := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{a: "pikachu", b: "pikachu", expected: 0},
+		{a: "pikachu", b: "pikchu", expected: 1},
+		{a: "pikachu", b: "pikahcu", expected: 1},
+		{a: "bulbasaur", b: "bulbsaur", expected: 1},
+		{a: "", b: "abc", expected: 3},
+		{a: "kitten", b: "sitting", expected: 3},
+	}
+	for _, c := range cases {
+		if actual := Distance(c.a, c.b); actual != c.expected {
+			t.Errorf("%q vs %q: Expected %v, Got %v", c.a, c.b, c.expected, actual)
+		}
+	}
+}
+
+// TestNormalizeName checks that user input is turned into API name form.
+func TestNormalizeName(t *testing.T) {
+	cases := map[string]string{
+		"Mr. Mime":         "mr-mime",
+		"  canalave city ": "canalave-city",
+		"farfetch'd":       "farfetchd",
+		"ho_oh":            "ho-oh",
+	}
+	for input, expected := range cases {
+		if actual := NormalizeName(input); actual != expected {
+			t.Errorf("%q: Expected %q, Got %q", input, expected, actual)
+		}
+	}
+}
+
+// TestFuzzyMatch checks ranking and typo tolerance.
+func TestFuzzyMatch(t *testing.T) {
+	names := []string{"pikachu", "pichu", "charmander", "charmeleon", "mr-mime", "mime-jr", "canalave-city-area", "pidgey"}
+
+	cases := []struct {
+		query    string
+		expected []string
+	}{
+		{query: "pikachu", expected: []string{"pikachu"}},
+		{query: "pikahcu", expected: []string{"pikachu"}},
+		{query: "charm", expected: []string{"charmander", "charmeleon"}},
+		{query: "mr mime", expected: []string{"mr-mime"}},
+		{query: "mrmime", expected: []string{"mr-mime"}},
+		{query: "mime", expected: []string{"mime-jr", "mr-mime"}},
+		{query: "canalave", expected: []string{"canalave-city-area"}},
+		{query: "canalve", expected: []string{"canalave-city-area"}},
+		{query: "xyz", expected: []string{}},
+	}
+	for _, c := range cases {
+		matches := FuzzyMatch(c.query, names, 0)
+		actual := []string{}
+		for _, match := range matches {
+			actual = append(actual, match.Name)
+		}
+		if len(actual) != len(c.expected) {
+			t.Errorf("%q: Expected %v, Got %v", c.query, c.expected, actual)
+			continue
+		}
+		for i := range actual {
+			if actual[i] != c.expected[i] {
+				t.Errorf("%q: Expected %v, Got %v", c.query, c.expected, actual)
+				break
+			}
+		}
+	}
+
+	if matches := FuzzyMatch("pi", names, 2); len(matches) != 2 {
+		t.Errorf("Expected the limit to cut matches to 2, Got %v", len(matches))
+	}
+}
@@ -0,0 +1,33 @@
+package internal
+
+// Generation is a range of the national Pokédex introduced together, with the region it came from.
+type Generation struct {
+	Number int
+	Region string
+	First  int // National dex number of the first species
+	Last   int // National dex number of the last species
+}
+
+// Generations lists every generation in order. Species past the last one are not
+// part of any generation until the table is extended.
+var Generations = []Generation{
+	{Number: 1, Region: "kanto", First: 1, Last: 151},
+	{Number: 2, Region: "johto", First: 152, Last: 251},
+	{Number: 3, Region: "hoenn", First: 252, Last: 386},
+	{Number: 4, Region: "sinnoh", First: 387, Last: 493},
+	{Number: 5, Region: "unova", First: 494, Last: 649},
+	{Number: 6, Region: "kalos", First: 650, Last: 721},
+	{Number: 7, Region: "alola", First: 722, Last: 809},
+	{Number: 8, Region: "galar", First: 810, Last: 905},
+	{Number: 9, Region: "paldea", First: 906, Last: 1025},
+}
+
+// GenerationOf returns the generation that introduced national dex number id.
+func GenerationOf(id int) (Generation, bool) {
+	for _, generation := range Generations {
+		if id >= generation.First && id <= generation.Last {
+			return generation, true
+		}
+	}
+	return Generation{}, false
+}
@@ -0,0 +1,33 @@
+package internal
+
+import "testing"
+
+func TestGenerationOf(t *testing.T) {
+	cases := []struct {
+		id       int
+		expected int // Generation number, 0 for none
+	}{
+		{id: 1, expected: 1},
+		{id: 151, expected: 1},
+		{id: 152, expected: 2},
+		{id: 493, expected: 4},
+		{id: 906, expected: 9},
+		{id: 1025, expected: 9},
+		{id: 0, expected: 0},
+		{id: 10034, expected: 0},
+	}
+
+	for _, c := range cases {
+		generation, _ := GenerationOf(c.id)
+		if generation.Number != c.expected {
+			t.Errorf("GenerationOf(%v) Expected %v, Got %v", c.id, c.expected, generation.Number)
+		}
+	}
+
+	// The table must cover the dex without gaps.
+	for i := 1; i < len(Generations); i++ {
+		if Generations[i].First != Generations[i-1].Last+1 {
+			t.Errorf("Generation %v should start right after generation %v", Generations[i].Number, Generations[i-1].Number)
+		}
+	}
+}
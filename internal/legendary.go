@@ -0,0 +1,34 @@
+package internal
+
+// legendaries holds the national dex numbers of the legendary and mythical species,
+// the ones the PokeAPI flags with is_legendary or is_mythical.
+var legendaries = map[int]bool{
+	// Kanto
+	144: true, 145: true, 146: true, 150: true, 151: true,
+	// Johto
+	243: true, 244: true, 245: true, 249: true, 250: true, 251: true,
+	// Hoenn
+	377: true, 378: true, 379: true, 380: true, 381: true, 382: true, 383: true, 384: true, 385: true, 386: true,
+	// Sinnoh
+	480: true, 481: true, 482: true, 483: true, 484: true, 485: true, 486: true, 487: true, 488: true,
+	489: true, 490: true, 491: true, 492: true, 493: true,
+	// Unova
+	494: true, 638: true, 639: true, 640: true, 641: true, 642: true, 643: true, 644: true, 645: true,
+	646: true, 647: true, 648: true, 649: true,
+	// Kalos
+	716: true, 717: true, 718: true, 719: true, 720: true, 721: true,
+	// Alola
+	772: true, 773: true, 785: true, 786: true, 787: true, 788: true, 789: true, 790: true, 791: true,
+	792: true, 800: true, 801: true, 802: true, 807: true, 808: true, 809: true,
+	// Galar
+	888: true, 889: true, 890: true, 891: true, 892: true, 893: true, 894: true, 895: true, 896: true,
+	897: true, 898: true, 905: true,
+	// Paldea
+	1001: true, 1002: true, 1003: true, 1004: true, 1007: true, 1008: true, 1014: true, 1015: true,
+	1016: true, 1017: true, 1024: true, 1025: true,
+}
+
+// IsLegendary reports whether national dex number id is a legendary or mythical species.
+func IsLegendary(id int) bool {
+	return legendaries[id]
+}
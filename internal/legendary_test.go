@@ -0,0 +1,22 @@
+package internal
+
+import "testing"
+
+func TestIsLegendary(t *testing.T) {
+	cases := []struct {
+		id       int
+		expected bool
+	}{
+		{id: 150, expected: true},  // Mewtwo
+		{id: 151, expected: true},  // Mew, mythical
+		{id: 149, expected: false}, // Dragonite
+		{id: 1025, expected: true}, // Pecharunt
+		{id: 0, expected: false},
+	}
+
+	for _, c := range cases {
+		if actual := IsLegendary(c.id); actual != c.expected {
+			t.Errorf("IsLegendary(%v) Expected %v, Got %v", c.id, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package internal
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+)
+
+// ColorMode is how many colors the terminal can show.
+type ColorMode int
+
+const (
+	ASCIIMode     ColorMode = iota // No color: characters by brightness
+	Color256Mode                   // The xterm 256-color palette
+	TrueColorMode                  // 24-bit color
+)
+
+// asciiRamp goes from the faintest to the densest character.
+const asciiRamp = " .:-=+*#%@"
+
+// DetectColorMode picks the best color mode from the terminal's environment.
+// getenv is usually os.Getenv; noColor is true when output isn't a color terminal.
+func DetectColorMode(getenv func(string) string, noColor bool) ColorMode {
+	if noColor || getenv("NO_COLOR") != "" || getenv("TERM") == "dumb" {
+		return ASCIIMode
+	}
+	colorTerm := strings.ToLower(getenv("COLORTERM"))
+	if colorTerm == "truecolor" || colorTerm == "24bit" {
+		return TrueColorMode
+	}
+	if strings.Contains(getenv("TERM"), "256color") {
+		return Color256Mode
+	}
+	return ASCIIMode
+}
+
+// opaque reports whether a pixel is solid enough to draw.
+func opaque(c color.Color) bool {
+	_, _, _, a := c.RGBA()
+	return a >= 0x8000
+}
+
+// rgb8 returns a color's 8-bit red, green and blue components.
+func rgb8(c color.Color) (uint8, uint8, uint8) {
+	r, g, b, _ := c.RGBA()
+	return uint8(r >> 8), uint8(g >> 8), uint8(b >> 8)
+}
+
+// cropToContent returns the smallest rectangle holding every opaque pixel.
+// Sprites have wide transparent borders that would only waste terminal space.
+func cropToContent(img image.Image) image.Rectangle {
+	bounds := img.Bounds()
+	crop := image.Rectangle{}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if opaque(img.At(x, y)) {
+				crop = crop.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	return crop
+}
+
+// scaledPixel returns the pixel of img at (x, y) of a width x height grid laid over area,
+// or nil when the point falls outside the area.
+func scaledPixel(img image.Image, area image.Rectangle, width, height, x, y int) color.Color {
+	if y >= height {
+		return nil
+	}
+	srcX := area.Min.X + x*area.Dx()/width
+	srcY := area.Min.Y + y*area.Dy()/height
+	return img.At(srcX, srcY)
+}
+
+// To256 maps a color to the nearest entry of the xterm 6x6x6 color cube.
+func To256(r, g, b uint8) int {
+	level := func(v uint8) int { return (int(v)*5 + 127) / 255 }
+	return 16 + 36*level(r) + 6*level(g) + level(b)
+}
+
+// fgCode returns the escape sequence setting the foreground color.
+func fgCode(mode ColorMode, c color.Color) string {
+	r, g, b := rgb8(c)
+	if mode == TrueColorMode {
+		return fmt.Sprintf("\x1b[38;2;%d;%d;%dm", r, g, b)
+	}
+	return fmt.Sprintf("\x1b[38;5;%dm", To256(r, g, b))
+}
+
+// bgCode returns the escape sequence setting the background color.
+func bgCode(mode ColorMode, c color.Color) string {
+	r, g, b := rgb8(c)
+	if mode == TrueColorMode {
+		return fmt.Sprintf("\x1b[48;2;%d;%d;%dm", r, g, b)
+	}
+	return fmt.Sprintf("\x1b[48;5;%dm", To256(r, g, b))
+}
+
+// RenderImage draws an image for the terminal, at most maxWidth characters wide.
+// Color modes use the upper half block so every character shows two pixels;
+// ASCII mode shows one character per two pixel rows, chosen by brightness.
+func RenderImage(img image.Image, mode ColorMode, maxWidth int) string {
+	area := cropToContent(img)
+	if area.Empty() {
+		return ""
+	}
+
+	width := area.Dx()
+	if width > maxWidth {
+		width = maxWidth
+	}
+	// Keep the aspect ratio; every terminal row holds two pixel rows.
+	height := area.Dy() * width / area.Dx()
+	if height < 1 {
+		height = 1
+	}
+
+	var sb strings.Builder
+	for y := 0; y < height; y += 2 {
+		for x := 0; x < width; x++ {
+			top := scaledPixel(img, area, width, height, x, y)
+			bottom := scaledPixel(img, area, width, height, x, y+1)
+			topOpaque := top != nil && opaque(top)
+			bottomOpaque := bottom != nil && opaque(bottom)
+
+			if mode == ASCIIMode {
+				sb.WriteByte(asciiChar(top, bottom, topOpaque, bottomOpaque))
+				continue
+			}
+			switch {
+			case topOpaque && bottomOpaque:
+				sb.WriteString(fgCode(mode, top) + bgCode(mode, bottom) + "▀\x1b[0m")
+			case topOpaque:
+				sb.WriteString(fgCode(mode, top) + "▀\x1b[0m")
+			case bottomOpaque:
+				sb.WriteString(fgCode(mode, bottom) + "▄\x1b[0m")
+			default:
+				sb.WriteByte(' ')
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// asciiChar picks a ramp character for the average brightness of two stacked pixels.
+func asciiChar(top, bottom color.Color, topOpaque, bottomOpaque bool) byte {
+	total, count := 0, 0
+	for _, pixel := range []struct {
+		c  color.Color
+		ok bool
+	}{{top, topOpaque}, {bottom, bottomOpaque}} {
+		if !pixel.ok {
+			continue
+		}
+		r, g, b := rgb8(pixel.c)
+		total += (299*int(r) + 587*int(g) + 114*int(b)) / 1000
+		count++
+	}
+	if count == 0 {
+		return ' '
+	}
+	// Dark pixels get dense characters, so the sprite reads on a dark terminal.
+	brightness := total / count
+	index := (255 - brightness) * (len(asciiRamp) - 2) / 255
+	return asciiRamp[index+1]
+}
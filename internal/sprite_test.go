@@ -0,0 +1,68 @@
+package internal
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+// testSprite returns a 10x10 transparent image with a 4x4 red square in the middle.
+func testSprite() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	for y := 3; y < 7; y++ {
+		for x := 3; x < 7; x++ {
+			img.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+	return img
+}
+
+// TestRenderImage checks cropping and the output of every color mode.
+func TestRenderImage(t *testing.T) {
+	img := testSprite()
+
+	ascii := RenderImage(img, ASCIIMode, 40)
+	lines := strings.Split(strings.TrimSuffix(ascii, "\n"), "\n")
+	if len(lines) != 2 || len(lines[0]) != 4 {
+		t.Errorf("Expected the 4x4 square cropped to 2 lines of 4 characters, Got %q", ascii)
+	}
+	if strings.Contains(ascii, "\x1b[") {
+		t.Errorf("Expected no escape codes in ASCII mode, Got %q", ascii)
+	}
+
+	trueColor := RenderImage(img, TrueColorMode, 40)
+	if !strings.Contains(trueColor, "\x1b[38;2;255;0;0m") || !strings.Contains(trueColor, "▀") {
+		t.Errorf("Expected truecolor half blocks, Got %q", trueColor)
+	}
+
+	palette := RenderImage(img, Color256Mode, 40)
+	if !strings.Contains(palette, "\x1b[38;5;196m") {
+		t.Errorf("Expected 256-color red (196), Got %q", palette)
+	}
+
+	if empty := RenderImage(image.NewRGBA(image.Rect(0, 0, 4, 4)), TrueColorMode, 40); empty != "" {
+		t.Errorf("Expected nothing for a transparent image, Got %q", empty)
+	}
+}
+
+// TestDetectColorMode checks terminal capability detection.
+func TestDetectColorMode(t *testing.T) {
+	cases := []struct {
+		env      map[string]string
+		noColor  bool
+		expected ColorMode
+	}{
+		{env: map[string]string{"COLORTERM": "truecolor", "TERM": "xterm-256color"}, expected: TrueColorMode},
+		{env: map[string]string{"TERM": "xterm-256color"}, expected: Color256Mode},
+		{env: map[string]string{"TERM": "xterm-256color"}, noColor: true, expected: ASCIIMode},
+		{env: map[string]string{"TERM": "dumb", "COLORTERM": "truecolor"}, expected: ASCIIMode},
+	}
+
+	for i, c := range cases {
+		getenv := func(key string) string { return c.env[key] }
+		if mode := DetectColorMode(getenv, c.noColor); mode != c.expected {
+			t.Errorf("Test case %v: Expected mode %v, Got %v", i, c.expected, mode)
+		}
+	}
+}
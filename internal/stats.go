@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"sort"
+)
+
+// StatNames lists the six stats in the order the PokeAPI returns them.
+var StatNames = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+// MaxIV is the highest individual value a stat can roll.
+const MaxIV = 31
+
+// natures maps each nature to the stat it raises and the stat it lowers.
+// Neutral natures raise and lower nothing.
+var natures = map[string][2]string{
+	"hardy":   {"", ""},
+	"lonely":  {"attack", "defense"},
+	"brave":   {"attack", "speed"},
+	"adamant": {"attack", "special-attack"},
+	"naughty": {"attack", "special-defense"},
+	"bold":    {"defense", "attack"},
+	"docile":  {"", ""},
+	"relaxed": {"defense", "speed"},
+	"impish":  {"defense", "special-attack"},
+	"lax":     {"defense", "special-defense"},
+	"timid":   {"speed", "attack"},
+	"hasty":   {"speed", "defense"},
+	"serious": {"", ""},
+	"jolly":   {"speed", "special-attack"},
+	"naive":   {"speed", "special-defense"},
+	"modest":  {"special-attack", "attack"},
+	"mild":    {"special-attack", "defense"},
+	"quiet":   {"special-attack", "speed"},
+	"bashful": {"", ""},
+	"rash":    {"special-attack", "special-defense"},
+	"calm":    {"special-defense", "attack"},
+	"gentle":  {"special-defense", "defense"},
+	"sassy":   {"special-defense", "speed"},
+	"careful": {"special-defense", "special-attack"},
+	"quirky":  {"", ""},
+}
+
+// NatureNames returns all 25 nature names in alphabetical order.
+func NatureNames() []string {
+	names := make([]string, 0, len(natures))
+	for name := range natures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsNature reports whether name is a known nature.
+func IsNature(name string) bool {
+	_, ok := natures[name]
+	return ok
+}
+
+// NatureEffect returns the stat a nature raises and the stat it lowers ("" for neutral natures).
+func NatureEffect(nature string) (raised, lowered string) {
+	effect := natures[nature]
+	return effect[0], effect[1]
+}
+
+// CalcStat computes an actual stat value with the standard (generation III onward) formula.
+// HP ignores the nature; every other stat is multiplied by 1.1 or 0.9 when the nature affects it.
+func CalcStat(stat string, base, iv, ev, level int, nature string) int {
+	core := (2*base + iv + ev/4) * level / 100
+	if stat == "hp" {
+		return core + level + 10
+	}
+
+	value := core + 5
+	raised, lowered := NatureEffect(nature)
+	switch stat {
+	case raised:
+		value = value * 110 / 100
+	case lowered:
+		value = value * 90 / 100
+	}
+	return value
+}
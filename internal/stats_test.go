@@ -0,0 +1,49 @@
+package internal
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestCalcStat checks the stat formula against worked examples,
+// including the Lv. 78 Garchomp from the games' official stat example.
+func TestCalcStat(t *testing.T) {
+	cases := []struct {
+		stat     string
+		base     int
+		iv       int
+		ev       int
+		level    int
+		nature   string
+		expected int
+	}{
+		{stat: "hp", base: 108, iv: 24, ev: 74, level: 78, nature: "adamant", expected: 289},
+		{stat: "attack", base: 130, iv: 12, ev: 190, level: 78, nature: "adamant", expected: 278},
+		{stat: "special-attack", base: 80, iv: 16, ev: 48, level: 78, nature: "adamant", expected: 135},
+		{stat: "speed", base: 102, iv: 5, ev: 23, level: 78, nature: "adamant", expected: 171},
+		{stat: "attack", base: 55, iv: 0, ev: 0, level: 5, nature: "hardy", expected: 10},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			actual := CalcStat(c.stat, c.base, c.iv, c.ev, c.level, c.nature)
+			if actual != c.expected {
+				t.Errorf("Expected %s: %v, Got: %v", c.stat, c.expected, actual)
+			}
+		})
+	}
+}
+
+// TestNatures checks that there are 25 natures and that effects are wired up.
+func TestNatures(t *testing.T) {
+	if len(NatureNames()) != 25 {
+		t.Errorf("Expected 25 natures, Got %v", len(NatureNames()))
+	}
+	raised, lowered := NatureEffect("modest")
+	if raised != "special-attack" || lowered != "attack" {
+		t.Errorf("Expected modest to be +special-attack/-attack, Got +%v/-%v", raised, lowered)
+	}
+	if !IsNature("quirky") || IsNature("grumpy") {
+		t.Errorf("IsNature returned the wrong answer")
+	}
+}
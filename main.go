@@ -3,6 +3,7 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
 	"os"
 	"time"
@@ -14,44 +15,51 @@ import (
 // It displays a prompt, reads user commands, and dispatches them to the proper handler.
 // The loop continues until standard input ends or the user issues an exit command.
 func main() {
-	scanner := bufio.NewScanner(os.Stdin)
-	color.New(color.FgCyan, color.Bold).Print("Pokedex > ")
+	savePath := flag.String("save", defaultSavePath(), "path of the save file holding your game")
+	fullScreen := flag.Bool("tui", false, "start in full-screen mode")
+	flag.Parse()
 
-	// configPTR keeps track of paging state for the PokeAPI.
-	configPTR := config{}
+	// configPTR keeps track of paging state for the PokeAPI and the rest of the session.
+	configPTR := config{indexPath: nameIndexPath(*savePath)}
 	pokedex := make(map[string]pokemonDetails)
 
+	// Continue the saved game, if there is one.
+	if err := loadSave(*savePath, &configPTR, pokedex); err != nil {
+		fmt.Fprintln(os.Stderr, "loading save file:", err)
+		os.Exit(1)
+	}
+
+	// pokedexcli serve runs the HTTP API instead of the REPL.
+	if flag.Arg(0) == "serve" {
+		if err := serve(flag.Args()[1:], *savePath, &configPTR, pokedex); err != nil {
+			fmt.Fprintln(os.Stderr, "serve:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *fullScreen {
+		if err := runTUI(*savePath, &configPTR, pokedex); err != nil {
+			fmt.Fprintln(os.Stderr, "full-screen mode:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	color.New(color.FgCyan, color.Bold).Print("Pokedex > ")
+
 	// Init new cache with given interval (interval determines when cacheEntries are cleared)
 	cachePtr := internal.NewCache(30 * time.Second)
 
 	// The REPL loop: waits for user input, dispatches commands, then re-prompts.
 	for scanner.Scan() {
-		userInput := scanner.Text()
-		cleanedWords := cleanInput(userInput)
-
-		if len(cleanedWords) > 0 {
-			command, exists := commandsMap[cleanedWords[0]]
-			if exists {
-				var err error
-				if (cleanedWords[0] == "explore" || cleanedWords[0] == "catch" || cleanedWords[0] == "inspect"|| cleanedWords[0] == "pokedex") && len(cleanedWords) > 1 {
-					err = command.callback(&configPTR, cachePtr, cleanedWords[1], pokedex)
-				} else if cleanedWords[0] == "explore" || cleanedWords[0] == "catch" {
-					color.New(color.FgHiRed, color.Bold).Println("Error: missing pokemon or location argument.")
-				} else {
-					err = command.callback(&configPTR, cachePtr, "", pokedex)
-				}
-
-				if err != nil {
-					fmt.Printf("Error occurred: %v\n", err)
-				}
-			} else {
-				fmt.Println("Unknown command")
-			}
-		}
-
+		runInput(&configPTR, cachePtr, pokedex, *savePath, scanner.Text())
 		color.New(color.FgCyan, color.Bold).Print("Pokedex > ")
 	}
 
+	savePlayTime(*savePath, &configPTR, pokedex)
+
 	// Detect and report any error that happened during input scanning.
 	if err := scanner.Err(); err != nil {
 		fmt.Fprintln(os.Stderr, "reading standard input:", err)
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
+	"github.com/fatih/color"
+)
+
+const (
+	defaultMapLimit = 20   // Location areas per map page unless --limit says otherwise
+	maxMapLimit     = 1000 // Largest page the PokeAPI is asked for
+)
+
+// pageLimit returns how many location areas a map page shows.
+func (configPtr *config) pageLimit() int {
+	if configPtr.mapLimit > 0 {
+		return configPtr.mapLimit
+	}
+	return defaultMapLimit
+}
+
+// areaPage returns the location area names of one page and the total number of areas.
+// With a game selected the page comes from that game's areas, otherwise from the PokeAPI,
+// whose pages are cached under the same URLs as its own next/previous links.
+func areaPage(configPtr *config, cachePtr *internal.Cache, offset, limit int) ([]string, int, error) {
+	if configPtr.Version != "" {
+		areas, err := versionAreas(configPtr, cachePtr)
+		if err != nil {
+			return nil, 0, err
+		}
+		if offset >= len(areas) {
+			return []string{}, len(areas), nil
+		}
+		end := offset + limit
+		if end > len(areas) {
+			end = len(areas)
+		}
+		return areas[offset:end], len(areas), nil
+	}
+
+	url := fmt.Sprintf("https://pokeapi.co/api/v2/location-area/?offset=%d&limit=%d", offset, limit)
+	val, err := fetchData(cachePtr, url)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var page locations
+	if err := json.Unmarshal(val, &page); err != nil {
+		return nil, 0, err
+	}
+
+	names := []string{}
+	for _, result := range page.Results {
+		names = append(names, result.Name)
+	}
+	return names, page.Count, nil
+}
+
+// totalAreas returns how many location areas there are, from the first page.
+func totalAreas(configPtr *config, cachePtr *internal.Cache) (int, error) {
+	_, total, err := areaPage(configPtr, cachePtr, 0, configPtr.pageLimit())
+	return total, err
+}
+
+// lastPageOffset returns the offset of the last page of total items.
+func lastPageOffset(total, limit int) int {
+	if total <= 0 {
+		return 0
+	}
+	return (total - 1) / limit * limit
+}
+
+// pageCount returns how many pages total items fill.
+func pageCount(total, limit int) int {
+	if total <= 0 {
+		return 1
+	}
+	return (total + limit - 1) / limit
+}
+
+// showMapPage prints the location areas of the page starting at offset,
+// followed by a "page X of Y" footer.
+func showMapPage(configPtr *config, cachePtr *internal.Cache, offset int) error {
+	limit := configPtr.pageLimit()
+	names, total, err := areaPage(configPtr, cachePtr, offset, limit)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		if configPtr.mapShown && offset == configPtr.mapOffset+limit {
+			color.New(color.FgHiBlack).Println("You're on the last page...")
+		} else {
+			color.New(color.FgHiBlack).Printf("There are only %d pages.\n", pageCount(total, limit))
+		}
+		return nil
+	}
+
+	// Print the names of all locations in the page
+	// Highlight each location name in green!
+	for _, name := range names {
+		color.New(color.FgHiGreen, color.Bold).Printf("%v\n", name)
+	}
+	configPtr.mapShown = true
+	configPtr.mapOffset = offset
+
+	color.New(color.FgHiBlack).Printf("Page %d of %d (%d areas)\n", offset/limit+1, pageCount(total, limit), total)
+	return nil
+}
+
+// allAreas returns every location area map can show: those of the selected game,
+// or all of the PokeAPI's in one request. That single list is cached like any other
+// response, and is cheaper than going through map's pages, which may not be cached
+// at all and take a request each.
+func allAreas(configPtr *config, cachePtr *internal.Cache) ([]string, error) {
+	if configPtr.Version != "" {
+		return versionAreas(configPtr, cachePtr)
+	}
+	list, err := fetchNamedList(cachePtr, "location-area")
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, result := range list.Results {
+		names = append(names, result.Name)
+	}
+	return names, nil
+}
+
+// filterAreas lists every location area whose name contains text.
+func filterAreas(configPtr *config, cachePtr *internal.Cache, text string) error {
+	areas, err := allAreas(configPtr, cachePtr)
+	if err != nil {
+		return err
+	}
+	matches := []string{}
+	for _, name := range areas {
+		if strings.Contains(name, text) {
+			matches = append(matches, name)
+		}
+	}
+
+	if len(matches) == 0 {
+		color.New(color.FgHiBlack).Printf("No location areas match %q.\n", text)
+		return nil
+	}
+	for _, name := range matches {
+		color.New(color.FgHiGreen, color.Bold).Printf("%v\n", name)
+	}
+	color.New(color.FgHiBlack).Printf("%d areas match %q\n", len(matches), text)
+	return nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestMapPaging checks the page math behind map's --last and page footer.
+func TestMapPaging(t *testing.T) {
+	cases := []struct {
+		total      int
+		limit      int
+		lastOffset int
+		pages      int
+	}{
+		{total: 1089, limit: 20, lastOffset: 1080, pages: 55},
+		{total: 1080, limit: 20, lastOffset: 1060, pages: 54},
+		{total: 5, limit: 50, lastOffset: 0, pages: 1},
+		{total: 0, limit: 20, lastOffset: 0, pages: 1},
+	}
+	for _, c := range cases {
+		if actual := lastPageOffset(c.total, c.limit); actual != c.lastOffset {
+			t.Errorf("last offset of %d/%d: Expected %d, Got %d", c.total, c.limit, c.lastOffset, actual)
+		}
+		if actual := pageCount(c.total, c.limit); actual != c.pages {
+			t.Errorf("pages of %d/%d: Expected %d, Got %d", c.total, c.limit, c.pages, actual)
+		}
+	}
+}
+
+// TestMapLimitRealigns checks that changing the page size keeps map on a page boundary,
+// and that --page without a number doesn't move.
+func TestMapLimitRealigns(t *testing.T) {
+	areas := []string{}
+	for i := 0; i < 100; i++ {
+		areas = append(areas, fmt.Sprintf("area-%d", i))
+	}
+	configPtr := &config{Version: "red", versionAreas: areas, mapShown: true, mapOffset: 40}
+
+	if err := commandMap(configPtr, nil, []string{"--limit", "50"}, nil); err != nil {
+		t.Fatalf("map --limit 50: %v", err)
+	}
+	if configPtr.mapOffset != 0 {
+		t.Errorf("Expected the page at offset 0, Got %d", configPtr.mapOffset)
+	}
+	if err := commandMap(configPtr, nil, []string{"--page"}, nil); err != nil {
+		t.Fatalf("map --page: %v", err)
+	}
+	if configPtr.mapOffset != 0 {
+		t.Errorf("Expected map --page to stay at offset 0, Got %d", configPtr.mapOffset)
+	}
+}
@@ -0,0 +1,365 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
+	"github.com/fatih/color"
+)
+
+// learnsetEntry is one way a Pokémon learns a move in a version group.
+type learnsetEntry struct {
+	Move   string
+	Method string // "level-up", "machine", "egg", "tutor", ...
+	Level  int    // Level it is learned at; 0 for methods without a level
+}
+
+// learnMethodOrder ranks learn methods for display; anything else sorts after tutor moves.
+var learnMethodOrder = map[string]int{"level-up": 0, "machine": 1, "egg": 2, "tutor": 3}
+
+// methodRank returns the display position of a learn method.
+func methodRank(method string) int {
+	if rank, ok := learnMethodOrder[method]; ok {
+		return rank
+	}
+	return len(learnMethodOrder)
+}
+
+// learnset lists how a Pokémon learns its moves in a version group,
+// sorted by method (level-up, machine, egg, tutor, others), then level, then name.
+func learnset(details pokemonDetails, versionGroup string) []learnsetEntry {
+	entries := []learnsetEntry{}
+	for _, move := range details.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			if detail.VersionGroup.Name != versionGroup {
+				continue
+			}
+			entries = append(entries, learnsetEntry{
+				Move:   move.Move.Name,
+				Method: detail.MoveLearnMethod.Name,
+				Level:  detail.LevelLearnedAt,
+			})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if methodRank(entries[i].Method) != methodRank(entries[j].Method) {
+			return methodRank(entries[i].Method) < methodRank(entries[j].Method)
+		}
+		if entries[i].Method != entries[j].Method {
+			return entries[i].Method < entries[j].Method
+		}
+		if entries[i].Level != entries[j].Level {
+			return entries[i].Level < entries[j].Level
+		}
+		return entries[i].Move < entries[j].Move
+	})
+	return entries
+}
+
+// englishEffect returns the English short effect text, with the effect chance filled in.
+func englishEffect(entries []effectEntry, effectChance *int) string {
+	for _, entry := range entries {
+		if entry.Language.Name != "en" {
+			continue
+		}
+		text := entry.ShortEffect
+		if text == "" {
+			text = entry.Effect
+		}
+		if effectChance != nil {
+			text = strings.ReplaceAll(text, "$effect_chance", strconv.Itoa(*effectChance))
+		}
+		return strings.Join(strings.Fields(text), " ")
+	}
+	return "(no English description)"
+}
+
+// fetchAbility looks up an ability by name.
+func fetchAbility(cachePtr *internal.Cache, abilityName string) (abilityDetails, error) {
+	var details abilityDetails
+
+	url := fmt.Sprintf("https://pokeapi.co/api/v2/ability/%v/", abilityName)
+	val, err := fetchData(cachePtr, url)
+	if err != nil {
+		return details, err
+	}
+
+	err = json.Unmarshal(val, &details)
+	return details, err
+}
+
+// sortedPokedexNames returns the caught species names in alphabetical order.
+func sortedPokedexNames(pokedex map[string]pokemonDetails) []string {
+	names := make([]string, 0, len(pokedex))
+	for name := range pokedex {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// move shows a move's battle data and effect, which caught species can learn it
+// and which owned Pokémon currently know it.
+func move(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	if len(args) == 0 {
+		color.New(color.FgHiRed, color.Bold).Println("Error: missing move argument.")
+		return nil
+	}
+	details, err := fetchMove(cachePtr, args[0])
+	if err != nil {
+		return err
+	}
+
+	power, accuracy := "-", "-"
+	if details.Power != nil {
+		power = strconv.Itoa(*details.Power)
+	}
+	if details.Accuracy != nil {
+		accuracy = strconv.Itoa(*details.Accuracy) + "%"
+	}
+
+	color.New(color.FgHiYellow, color.Bold).Printf("Move: %v\n", details.Name)
+	color.New(color.Bold).Printf("Type: %v\nCategory: %v\n", details.Type.Name, details.DamageClass.Name)
+	color.New(color.Bold).Printf("Power: %v  Accuracy: %v  PP: %d\n", power, accuracy, details.PP)
+	if details.Priority != 0 {
+		color.New(color.Bold).Printf("Priority: %+d\n", details.Priority)
+	}
+	color.New(color.FgWhite).Printf("Effect: %v\n", englishEffect(details.EffectEntries, details.EffectChance))
+
+	learners := []string{}
+	for _, name := range sortedPokedexNames(pokedex) {
+		for _, known := range pokedex[name].Moves {
+			if known.Move.Name == details.Name {
+				learners = append(learners, name)
+				break
+			}
+		}
+	}
+	color.New(color.FgCyan, color.Bold).Println("Your Pokémon that can learn it:")
+	if len(learners) == 0 {
+		color.New(color.FgHiBlack).Println("  (none)")
+	} else {
+		color.New(color.FgHiGreen).Printf("  %v\n", strings.Join(learners, ", "))
+	}
+
+	knowers := []string{}
+	for _, owned := range configPtr.allOwned() {
+		if knowsMove(owned, details.Name) {
+			knowers = append(knowers, fmt.Sprintf("#%d %v", owned.ID, owned.displayName()))
+		}
+	}
+	if len(knowers) > 0 {
+		color.New(color.FgCyan, color.Bold).Println("Your Pokémon that know it:")
+		color.New(color.FgHiGreen).Printf("  %v\n", strings.Join(knowers, ", "))
+	}
+	return nil
+}
+
+// ability shows an ability's effect and which caught species have it.
+func ability(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	if len(args) == 0 {
+		color.New(color.FgHiRed, color.Bold).Println("Error: missing ability argument.")
+		return nil
+	}
+	details, err := fetchAbility(cachePtr, args[0])
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgHiYellow, color.Bold).Printf("Ability: %v\n", details.Name)
+	color.New(color.Bold).Printf("Introduced in: %v\n", details.Generation.Name)
+	color.New(color.FgWhite).Printf("Effect: %v\n", englishEffect(details.EffectEntries, nil))
+
+	color.New(color.FgCyan, color.Bold).Println("Your Pokémon with this ability:")
+	found := false
+	for _, name := range sortedPokedexNames(pokedex) {
+		for _, a := range pokedex[name].Abilities {
+			if a.Ability.Name != details.Name {
+				continue
+			}
+			found = true
+			color.New(color.FgHiGreen).Printf("  - %v", name)
+			if a.IsHidden {
+				color.New(color.FgHiBlack).Print(" (hidden ability)")
+			}
+			fmt.Println()
+		}
+	}
+	if !found {
+		color.New(color.FgHiBlack).Println("  (none)")
+	}
+	return nil
+}
+
+// printInspectSections prints the optional --moves and --abilities sections of inspect.
+func printInspectSections(configPtr *config, details pokemonDetails, flags map[string]string) {
+	if _, ok := flags["abilities"]; ok {
+		color.New(color.FgCyan, color.Bold).Println("Abilities:")
+		for _, a := range details.Abilities {
+			color.New(color.Bold).Printf("  %d. %v", a.Slot, a.Ability.Name)
+			if a.IsHidden {
+				color.New(color.FgHiBlack).Print(" (hidden ability)")
+			}
+			fmt.Println()
+		}
+	}
+
+	if _, ok := flags["moves"]; ok {
+		versionGroup := flags["version-group"]
+		if versionGroup == "" {
+			versionGroup = learnVersionGroup(details, configPtr.VersionGroup)
+		}
+		entries := learnset(details, versionGroup)
+		color.New(color.FgCyan, color.Bold).Printf("Moves (%v):\n", versionGroup)
+		if len(entries) == 0 {
+			color.New(color.FgHiBlack).Printf("  (no moves in %v)\n", versionGroup)
+		}
+		for _, entry := range entries {
+			color.New(color.Bold).Printf("  - %v", entry.Move)
+			if entry.Method == "level-up" {
+				color.New(color.FgHiBlack).Printf(" (level-up, Lv. %d)\n", entry.Level)
+			} else {
+				color.New(color.FgHiBlack).Printf(" (%v)\n", entry.Method)
+			}
+		}
+	}
+}
+
+// versionGroupsOf lists the version groups a Pokémon has moves in, oldest first.
+func versionGroupsOf(details pokemonDetails) []string {
+	ids := make(map[string]int)
+	for _, move := range details.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			ids[detail.VersionGroup.Name] = idFromURL(detail.VersionGroup.URL)
+		}
+	}
+
+	groups := make([]string, 0, len(ids))
+	for name := range ids {
+		groups = append(groups, name)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if ids[groups[i]] != ids[groups[j]] {
+			return ids[groups[i]] < ids[groups[j]]
+		}
+		return groups[i] < groups[j]
+	})
+	return groups
+}
+
+// learnsetGame resolves learnset --game to one of groups, the version groups a Pokémon
+// has moves in. Like walk --game it takes a game ("sword"), and it also takes its
+// version group ("sword-shield"). It tells the user the valid games if there is no match.
+func learnsetGame(cachePtr *internal.Cache, game string, groups []string) (string, bool, error) {
+	versionGroup := game
+	if !contains(groups, game) && game != "" {
+		var details versionDetails
+		err := fetchResource(cachePtr, "version", game, &details)
+		if err != nil && !isNotFound(err) {
+			return "", false, err
+		}
+		versionGroup = details.VersionGroup.Name
+	}
+	if !contains(groups, versionGroup) {
+		color.New(color.FgHiRed, color.Bold).Printf("Unknown game %v. Games with moves: %v\n", game, strings.Join(groups, ", "))
+		return "", false, nil
+	}
+	return versionGroup, true, nil
+}
+
+// knownLearnMethod reports whether method is one of the PokeAPI's move learn methods.
+// If it isn't, it tells the user which methods there are.
+func knownLearnMethod(cachePtr *internal.Cache, method string) (bool, error) {
+	list, err := fetchNamedList(cachePtr, "move-learn-method")
+	if err != nil {
+		return false, err
+	}
+	known := []string{}
+	for _, result := range list.Results {
+		if result.Name == method {
+			return true, nil
+		}
+		known = append(known, result.Name)
+	}
+	color.New(color.FgHiRed, color.Bold).Printf("Unknown learn method %v. Choose one of: %v\n", method, strings.Join(known, ", "))
+	return false, nil
+}
+
+// learnsetCmd shows a table of the moves a Pokémon learns in a game,
+// optionally limited to one learn method:
+// learnset <pokemon> [--game sword|sword-shield] [--method level-up|machine|egg|tutor].
+func learnsetCmd(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	positional, flags := parseFlags(args)
+	if len(positional) == 0 {
+		color.New(color.FgHiRed, color.Bold).Println("Error: missing pokemon argument.")
+		return nil
+	}
+
+	// Caught Pokémon are already known; anything else is fetched.
+	details, ok := pokedex[positional[0]]
+	if !ok {
+		var err error
+		details, err = fetchPokemon(cachePtr, positional[0])
+		if err != nil {
+			return err
+		}
+	}
+
+	versionGroup := learnVersionGroup(details, configPtr.VersionGroup)
+	if game, ok := flags["game"]; ok {
+		var known bool
+		var err error
+		versionGroup, known, err = learnsetGame(cachePtr, game, versionGroupsOf(details))
+		if err != nil || !known {
+			return err
+		}
+	}
+	method := flags["method"]
+	if _, ok := flags["method"]; ok {
+		if known, err := knownLearnMethod(cachePtr, method); err != nil || !known {
+			return err
+		}
+	}
+
+	entries := []learnsetEntry{}
+	for _, entry := range learnset(details, versionGroup) {
+		if method == "" || entry.Method == method {
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) == 0 {
+		color.New(color.FgHiRed, color.Bold).Printf("%v has no %vmoves in %v.\n", details.Name, strings.TrimPrefix(method+" ", " "), versionGroup)
+		color.New(color.FgHiBlack).Printf("Games with moves: %v\n", strings.Join(versionGroupsOf(details), ", "))
+		return nil
+	}
+
+	color.New(color.FgHiYellow, color.Bold).Printf("%v learnset in %v:\n", details.Name, versionGroup)
+	rows := [][]string{}
+	for _, entry := range entries {
+		level := "-"
+		if entry.Method == "level-up" {
+			level = strconv.Itoa(entry.Level)
+		}
+		rows = append(rows, []string{level, entry.Move, entry.Method})
+	}
+	methodColors := map[string]*color.Color{
+		"level-up": color.New(color.FgHiGreen),
+		"machine":  color.New(color.FgHiBlue),
+		"egg":      color.New(color.FgHiYellow),
+		"tutor":    color.New(color.FgHiMagenta),
+	}
+	printTable([]string{"Lv.", "Move", "Method"}, rows, func(row, col int) *color.Color {
+		if col == 1 {
+			return color.New(color.Bold)
+		}
+		if col == 2 {
+			return methodColors[entries[row].Method]
+		}
+		return nil
+	})
+	return nil
+}
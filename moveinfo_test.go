@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
+)
+
+// TestLearnset checks that a version group's learnset is filtered and sorted.
+func TestLearnset(t *testing.T) {
+	var details pokemonDetails
+	if err := json.Unmarshal([]byte(testPokemonMoves), &details); err != nil {
+		t.Fatalf("could not parse test pokemon: %v", err)
+	}
+
+	entries := learnset(details, "red-blue")
+	expected := []string{"growl", "thunder-shock", "thunder-wave", "quick-attack", "swift", "thunderbolt"}
+	if len(entries) != len(expected) {
+		t.Fatalf("Expected %v entries, Got %v", len(expected), entries)
+	}
+	for i := range entries {
+		if entries[i].Move != expected[i] {
+			t.Errorf("Expected %v at position %v, Got %v", expected[i], i, entries[i].Move)
+		}
+	}
+	if last := entries[len(entries)-1]; last.Method != "machine" {
+		t.Errorf("Expected machine moves after level-up moves, Got %v", last.Method)
+	}
+}
+
+// TestEnglishEffect checks language selection and effect chance substitution.
+func TestEnglishEffect(t *testing.T) {
+	var entries []effectEntry
+	data := `[
+		{"short_effect": "Hat eine Chance", "language": {"name": "de"}},
+		{"short_effect": "Has a $effect_chance% chance to  paralyze the target.", "language": {"name": "en"}}
+	]`
+	if err := json.Unmarshal([]byte(data), &entries); err != nil {
+		t.Fatalf("could not parse effect entries: %v", err)
+	}
+
+	chance := 10
+	if text := englishEffect(entries, &chance); text != "Has a 10% chance to paralyze the target." {
+		t.Errorf("Unexpected effect text: %q", text)
+	}
+	if text := englishEffect(nil, nil); text != "(no English description)" {
+		t.Errorf("Unexpected effect text: %q", text)
+	}
+}
+
+// TestVersionGroupsOf checks that version groups are listed oldest first.
+func TestVersionGroupsOf(t *testing.T) {
+	var details pokemonDetails
+	if err := json.Unmarshal([]byte(testPokemonMoves), &details); err != nil {
+		t.Fatalf("could not parse test pokemon: %v", err)
+	}
+
+	groups := versionGroupsOf(details)
+	if len(groups) != 2 || groups[0] != "red-blue" || groups[1] != "x-y" {
+		t.Errorf("Expected [red-blue x-y], Got %v", groups)
+	}
+}
+
+// TestLearnsetGame checks that learnset --game takes a game or its version group,
+// and rejects games the Pokémon has no moves in.
+func TestLearnsetGame(t *testing.T) {
+	cachePtr := internal.NewCache(time.Minute)
+	cachePtr.Add("https://pokeapi.co/api/v2/version/red/", []byte(`{"name": "red", "version_group": {"name": "red-blue"}}`))
+	cachePtr.Add("https://pokeapi.co/api/v2/version/sword/", []byte(`{"name": "sword", "version_group": {"name": "sword-shield"}}`))
+	groups := []string{"red-blue", "yellow"}
+
+	cases := []struct {
+		game     string
+		expected string // "" when the game is rejected
+	}{
+		{game: "red-blue", expected: "red-blue"},
+		{game: "red", expected: "red-blue"},
+		{game: "sword", expected: ""},
+		{game: "", expected: ""},
+	}
+	for _, c := range cases {
+		versionGroup, known, err := learnsetGame(cachePtr, c.game, groups)
+		if err != nil {
+			t.Fatalf("%q: %v", c.game, err)
+		}
+		if known != (c.expected != "") || versionGroup != c.expected {
+			t.Errorf("%q: Expected %q, Got %q (known %v)", c.game, c.expected, versionGroup, known)
+		}
+	}
+}
+
+// TestKnownLearnMethod checks learnset --method against the PokeAPI's learn methods.
+func TestKnownLearnMethod(t *testing.T) {
+	cachePtr := internal.NewCache(time.Minute)
+	cachePtr.Add("https://pokeapi.co/api/v2/move-learn-method/?offset=0&limit=100000",
+		[]byte(`{"count": 2, "results": [{"name": "level-up"}, {"name": "machine"}]}`))
+
+	for method, expected := range map[string]bool{"level-up": true, "levelup": false} {
+		known, err := knownLearnMethod(cachePtr, method)
+		if err != nil || known != expected {
+			t.Errorf("%v: Expected %v, Got %v (%v)", method, expected, known, err)
+		}
+	}
+}
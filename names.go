@@ -0,0 +1,236 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
+	"github.com/fatih/color"
+)
+
+// nameIndexMaxAge is how long the name index on disk is used before it is rebuilt.
+const nameIndexMaxAge = 7 * 24 * time.Hour
+
+// nameKinds are the kinds of names search knows, with the PokeAPI list endpoint of each.
+var nameKinds = []struct {
+	kind     string
+	endpoint string
+	label    string // How messages call a name of this kind
+}{
+	{kind: "pokemon", endpoint: "pokemon", label: "Pokémon"},
+	{kind: "move", endpoint: "move", label: "move"},
+	{kind: "ability", endpoint: "ability", label: "ability"},
+	{kind: "item", endpoint: "item", label: "item"},
+	{kind: "location", endpoint: "location", label: "location"},
+	{kind: "area", endpoint: "location-area", label: "location area"},
+}
+
+// nameIndex holds every name of the PokeAPI list endpoints, by kind.
+// It is kept on disk next to the save file so it is only fetched once a week.
+type nameIndex struct {
+	BuiltAt time.Time           `json:"built_at"`
+	Names   map[string][]string `json:"names"`
+}
+
+// nameIndexPath returns where the name index is stored for a save file.
+func nameIndexPath(savePath string) string {
+	return filepath.Join(filepath.Dir(savePath), "names.json")
+}
+
+// loadNameIndex returns the name index, reading it from disk or building it
+// from the PokeAPI when there is none yet, it is too old, or refresh is set.
+func loadNameIndex(configPtr *config, cachePtr *internal.Cache, refresh bool) (*nameIndex, error) {
+	if configPtr.names != nil && !refresh {
+		return configPtr.names, nil
+	}
+
+	if configPtr.indexPath != "" && !refresh {
+		var index nameIndex
+		data, err := os.ReadFile(configPtr.indexPath)
+		if err == nil && json.Unmarshal(data, &index) == nil && time.Since(index.BuiltAt) < nameIndexMaxAge {
+			configPtr.names = &index
+			return configPtr.names, nil
+		}
+	}
+
+	color.New(color.FgHiBlack).Println("Building the name index...")
+	index := nameIndex{BuiltAt: time.Now(), Names: make(map[string][]string)}
+	for _, k := range nameKinds {
+		list, err := fetchNamedList(cachePtr, k.endpoint)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(list.Results))
+		for _, result := range list.Results {
+			names = append(names, result.Name)
+		}
+		index.Names[k.kind] = names
+	}
+	configPtr.names = &index
+
+	// Without a stored index the next session simply builds it again.
+	if configPtr.indexPath != "" {
+		if err := writeNameIndex(configPtr.indexPath, index); err != nil {
+			color.New(color.FgHiBlack).Printf("Could not store the name index: %v\n", err)
+		}
+	}
+	return configPtr.names, nil
+}
+
+// writeNameIndex stores the name index, through a temporary file like writeSave.
+func writeNameIndex(path string, index nameIndex) error {
+	data, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// knownNames returns every name of a kind, or nil when the index can't be loaded.
+func knownNames(configPtr *config, cachePtr *internal.Cache, kind string) []string {
+	index, err := loadNameIndex(configPtr, cachePtr, false)
+	if err != nil {
+		return nil
+	}
+	return index.Names[kind]
+}
+
+// similarNames returns up to three names close to name. If name itself is
+// among names there is nothing to suggest, so it returns none.
+func similarNames(name string, names []string) []string {
+	suggestions := []string{}
+	for _, match := range internal.FuzzyMatch(name, names, 3) {
+		if match.Name == name {
+			return nil
+		}
+		suggestions = append(suggestions, match.Name)
+	}
+	return suggestions
+}
+
+// printDidYouMean prints suggestions for a mistyped name and reports whether there were any.
+func printDidYouMean(suggestions []string) bool {
+	if len(suggestions) == 0 {
+		return false
+	}
+	color.New(color.FgHiYellow).Printf("Did you mean: %v?\n", strings.Join(suggestions, ", "))
+	return true
+}
+
+// reportUnknown handles err from looking up name, a name of kind. When the PokeAPI
+// doesn't know the name and there are similar ones, it tells the user and returns nil;
+// any other error, or a name with nothing like it, is returned as is.
+func reportUnknown(configPtr *config, cachePtr *internal.Cache, err error, kind, name string) error {
+	if !isNotFound(err) {
+		return err
+	}
+	suggestions := similarNames(name, knownNames(configPtr, cachePtr, kind))
+	if len(suggestions) == 0 {
+		return err
+	}
+	label := kind
+	for _, k := range nameKinds {
+		if k.kind == kind {
+			label = k.label
+		}
+	}
+	color.New(color.FgHiRed, color.Bold).Printf("There is no %v called %v.\n", label, name)
+	printDidYouMean(suggestions)
+	return nil
+}
+
+// searchResult is a name found by search, with its kind.
+type searchResult struct {
+	kind string
+	internal.Match
+}
+
+// search finds Pokémon, moves, abilities, items, locations and areas by approximate name,
+// e.g. search mr mime or search pewtr --kind location. --limit sets how many results
+// are shown and --refresh rebuilds the name index.
+func search(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	positional, flags := parseFlags(args, "refresh")
+	_, refresh := flags["refresh"]
+	if len(positional) == 0 && !refresh {
+		color.New(color.FgHiRed, color.Bold).Println("Error: missing search query.")
+		return nil
+	}
+
+	kind := flags["kind"]
+	kinds := []string{}
+	for _, k := range nameKinds {
+		if kind == "" || k.kind == kind {
+			kinds = append(kinds, k.kind)
+		}
+	}
+	if len(kinds) == 0 {
+		valid := []string{}
+		for _, k := range nameKinds {
+			valid = append(valid, k.kind)
+		}
+		color.New(color.FgHiRed, color.Bold).Printf("Unknown kind %v. Use one of: %v\n", kind, strings.Join(valid, ", "))
+		return nil
+	}
+
+	limit := 10
+	if value, ok := flags["limit"]; ok {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			color.New(color.FgHiRed, color.Bold).Println("The limit must be a positive number.")
+			return nil
+		}
+		limit = parsed
+	}
+
+	index, err := loadNameIndex(configPtr, cachePtr, refresh)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		color.New(color.FgCyan, color.Bold).Println("Name index rebuilt.")
+		return nil
+	}
+
+	query := strings.Join(positional, " ")
+	results := []searchResult{}
+	for _, k := range kinds {
+		for _, match := range internal.FuzzyMatch(query, index.Names[k], limit) {
+			results = append(results, searchResult{kind: k, Match: match})
+		}
+	}
+	// Each kind is ranked already; merging keeps the best matches of all kinds first.
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score < results[j].Score
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	if len(results) == 0 {
+		color.New(color.FgHiBlack).Printf("Nothing matches %q.\n", query)
+		return nil
+	}
+	rows := [][]string{}
+	for _, result := range results {
+		rows = append(rows, []string{result.Name, result.kind})
+	}
+	printTable([]string{"Name", "Kind"}, rows, func(row, col int) *color.Color {
+		if col == 0 {
+			return color.New(color.FgHiGreen, color.Bold)
+		}
+		return color.New(color.FgHiBlack)
+	})
+	return nil
+}
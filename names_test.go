@@ -0,0 +1,43 @@
+package main
+
+import "testing"
+
+// TestSimilarNames checks that suggestions are offered only for names that don't exist.
+func TestSimilarNames(t *testing.T) {
+	names := []string{"pikachu", "pichu", "raichu"}
+
+	if suggestions := similarNames("pikachu", names); len(suggestions) != 0 {
+		t.Errorf("Expected no suggestions for an existing name, Got %v", suggestions)
+	}
+	if suggestions := similarNames("pikahcu", names); len(suggestions) != 1 || suggestions[0] != "pikachu" {
+		t.Errorf("Expected [pikachu], Got %v", suggestions)
+	}
+	if suggestions := similarNames("bulbasaur", names); len(suggestions) != 0 {
+		t.Errorf("Expected no suggestions, Got %v", suggestions)
+	}
+}
+
+// TestReportUnknown checks that only names the PokeAPI doesn't know get suggestions.
+func TestReportUnknown(t *testing.T) {
+	configPtr := &config{names: &nameIndex{Names: map[string][]string{"area": {"route-1-area", "route-2-area"}}}}
+	notFound := &statusError{URL: "x", StatusCode: 404}
+	failed := &statusError{URL: "x", StatusCode: 500}
+
+	cases := []struct {
+		err      error
+		name     string
+		expected error
+	}{
+		// Reported with a did-you-mean
+		{err: notFound, name: "route-1-arae", expected: nil},
+		// Nothing like it, so the error stays
+		{err: notFound, name: "zzzzzzzz", expected: notFound},
+		// The PokeAPI failing is not a typo
+		{err: failed, name: "route-1-arae", expected: failed},
+	}
+	for _, c := range cases {
+		if actual := reportUnknown(configPtr, nil, c.err, "area", c.name); actual != c.expected {
+			t.Errorf("%v with %v: Expected %v, Got %v", c.name, c.err, c.expected, actual)
+		}
+	}
+}
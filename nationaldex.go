@@ -0,0 +1,234 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
+	"github.com/fatih/color"
+)
+
+// dexEntry is one species of the national Pokédex.
+type dexEntry struct {
+	ID   int
+	Name string
+}
+
+// dexStatus holds the national dex numbers of the species the trainer has seen and caught.
+// Every caught species counts as seen.
+type dexStatus struct {
+	seen   map[int]bool
+	caught map[int]bool
+}
+
+// dexProgress is how far the trainer is with one part of the national dex.
+type dexProgress struct {
+	Label  string
+	Region string
+	Seen   int
+	Caught int
+	Total  int
+}
+
+// nationalDex returns every species in national dex order.
+// The list is kept for the session, as it is the same for every game.
+func nationalDex(configPtr *config, cachePtr *internal.Cache) ([]dexEntry, error) {
+	if configPtr.nationalDex != nil {
+		return configPtr.nationalDex, nil
+	}
+	list, err := fetchNamedList(cachePtr, "pokemon-species")
+	if err != nil {
+		return nil, err
+	}
+	entries := []dexEntry{}
+	for _, result := range list.Results {
+		if id := idFromURL(result.URL); id > 0 {
+			entries = append(entries, dexEntry{ID: id, Name: result.Name})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+	configPtr.nationalDex = entries
+	return entries, nil
+}
+
+// speciesID returns the national dex number of a Pokémon name, or 0 if it has none.
+// Form names such as "wormadam-plant" are cut back a word at a time until a species matches.
+func speciesID(name string, ids map[string]int) int {
+	for {
+		if id, ok := ids[name]; ok {
+			return id
+		}
+		cut := strings.LastIndex(name, "-")
+		if cut < 0 {
+			return 0
+		}
+		name = name[:cut]
+	}
+}
+
+// trainerDexStatus works out which species of entries the trainer has seen and caught.
+func trainerDexStatus(configPtr *config, entries []dexEntry, pokedex map[string]pokemonDetails) dexStatus {
+	ids := make(map[string]int, len(entries))
+	for _, entry := range entries {
+		ids[entry.Name] = entry.ID
+	}
+	status := dexStatus{seen: make(map[int]bool), caught: make(map[int]bool)}
+	for name := range configPtr.Seen {
+		if id := speciesID(name, ids); id > 0 {
+			status.seen[id] = true
+		}
+	}
+	for name, details := range pokedex {
+		id := idFromURL(details.Species.URL)
+		if id == 0 {
+			id = speciesID(name, ids)
+		}
+		if id > 0 {
+			status.seen[id] = true
+			status.caught[id] = true
+		}
+	}
+	return status
+}
+
+// dexCompletion counts the seen and caught species of each generation in entries,
+// followed by the totals of all of them.
+func dexCompletion(entries []dexEntry, status dexStatus) []dexProgress {
+	progress := []dexProgress{}
+	for _, generation := range internal.Generations {
+		progress = append(progress, dexProgress{Label: strconv.Itoa(generation.Number), Region: generation.Region})
+	}
+	total := dexProgress{Label: "all"}
+	for _, entry := range entries {
+		counts := []*dexProgress{&total}
+		if generation, ok := internal.GenerationOf(entry.ID); ok {
+			counts = append(counts, &progress[generation.Number-1])
+		}
+		for _, count := range counts {
+			count.Total++
+			if status.seen[entry.ID] {
+				count.Seen++
+			}
+			if status.caught[entry.ID] {
+				count.Caught++
+			}
+		}
+	}
+	return append(progress, total)
+}
+
+// percent formats part of total as a percentage with one decimal.
+func percent(part, total int) string {
+	if total == 0 {
+		return "0.0%"
+	}
+	return fmt.Sprintf("%.1f%%", float64(part)*100/float64(total))
+}
+
+// typeSpecies returns the national dex numbers of the species whose default form has typeName.
+func typeSpecies(cachePtr *internal.Cache, typeName string) (map[int]bool, error) {
+	details, err := fetchType(cachePtr, typeName)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[int]bool)
+	for _, p := range details.Pokemon {
+		// Only default forms have the same ID as their species.
+		if id := idFromURL(p.Pokemon.URL); id > 0 && id < 10000 {
+			ids[id] = true
+		}
+	}
+	return ids, nil
+}
+
+// showNationalDex lists the national dex in order with a seen/caught mark for each species,
+// then the completion of the listed species. With missing it lists only species not caught yet.
+func showNationalDex(configPtr *config, cachePtr *internal.Cache, pokedex map[string]pokemonDetails, typeName string, missing bool) error {
+	entries, err := nationalDex(configPtr, cachePtr)
+	if err != nil {
+		return err
+	}
+	if typeName != "" {
+		ofType, err := typeSpecies(cachePtr, typeName)
+		if err != nil {
+			return err
+		}
+		filtered := []dexEntry{}
+		for _, entry := range entries {
+			if ofType[entry.ID] {
+				filtered = append(filtered, entry)
+			}
+		}
+		entries = filtered
+	}
+	status := trainerDexStatus(configPtr, entries, pokedex)
+
+	title := "National Pokedex"
+	if missing {
+		title = "Not caught yet"
+	}
+	if typeName != "" {
+		title += " (" + typeName + " type)"
+	}
+	color.New(color.FgCyan, color.Bold).Println(title + ":")
+	color.New(color.FgHiBlack).Println("● caught  ◐ seen  ○ unknown")
+
+	listed := 0
+	for _, entry := range entries {
+		if missing && status.caught[entry.ID] {
+			continue
+		}
+		switch {
+		case status.caught[entry.ID]:
+			color.New(color.FgHiGreen).Print("● ")
+			color.New(color.Bold).Printf("#%04d %v\n", entry.ID, entry.Name)
+		case status.seen[entry.ID]:
+			color.New(color.FgHiYellow).Print("◐ ")
+			fmt.Printf("#%04d %v\n", entry.ID, entry.Name)
+		default:
+			color.New(color.FgHiBlack).Printf("○ #%04d %v\n", entry.ID, entry.Name)
+		}
+		listed++
+	}
+	if missing && listed == 0 {
+		color.New(color.FgHiGreen, color.Bold).Println("Nothing! You caught every one of them.")
+	}
+
+	progress := dexCompletion(entries, status)
+	total := progress[len(progress)-1]
+	color.New(color.FgHiBlack).Printf("Seen %d/%d (%v)  Caught %d/%d (%v)\n",
+		total.Seen, total.Total, percent(total.Seen, total.Total),
+		total.Caught, total.Total, percent(total.Caught, total.Total))
+	return nil
+}
+
+// showDexCompletion prints the seen and caught percentages of every generation and of the whole national dex.
+func showDexCompletion(configPtr *config, cachePtr *internal.Cache, pokedex map[string]pokemonDetails) error {
+	entries, err := nationalDex(configPtr, cachePtr)
+	if err != nil {
+		return err
+	}
+	progress := dexCompletion(entries, trainerDexStatus(configPtr, entries, pokedex))
+
+	rows := [][]string{}
+	for _, p := range progress {
+		rows = append(rows, []string{
+			p.Label, p.Region,
+			fmt.Sprintf("%d/%d", p.Seen, p.Total), percent(p.Seen, p.Total),
+			fmt.Sprintf("%d/%d", p.Caught, p.Total), percent(p.Caught, p.Total),
+		})
+	}
+	color.New(color.FgCyan, color.Bold).Println("Pokedex completion:")
+	printTable([]string{"Gen", "Region", "Seen", "%", "Caught", "%"}, rows, func(row, col int) *color.Color {
+		if row == len(rows)-1 {
+			return color.New(color.Bold)
+		}
+		if col == 5 && progress[row].Total > 0 && progress[row].Caught == progress[row].Total {
+			return color.New(color.FgHiGreen, color.Bold)
+		}
+		return nil
+	})
+	return nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import "testing"
+
+func TestSpeciesID(t *testing.T) {
+	ids := map[string]int{"porygon": 137, "porygon-z": 474, "wormadam": 413, "mr-mime": 122}
+	cases := []struct {
+		name     string
+		expected int
+	}{
+		{name: "porygon", expected: 137},
+		{name: "porygon-z", expected: 474},
+		{name: "wormadam-plant", expected: 413},
+		{name: "mr-mime-galar", expected: 122},
+		{name: "missingno", expected: 0},
+	}
+
+	for _, c := range cases {
+		if actual := speciesID(c.name, ids); actual != c.expected {
+			t.Errorf("speciesID(%v) Expected %v, Got %v", c.name, c.expected, actual)
+		}
+	}
+}
+
+func TestDexCompletion(t *testing.T) {
+	entries := []dexEntry{{1, "bulbasaur"}, {4, "charmander"}, {25, "pikachu"}, {152, "chikorita"}, {1100, "future-mon"}}
+
+	var raichuAlola pokemonDetails
+	raichuAlola.Species.URL = "https://pokeapi.co/api/v2/pokemon-species/25/"
+	configPtr := &config{Seen: map[string]bool{"charmander": true, "chikorita": true}}
+	pokedex := map[string]pokemonDetails{"bulbasaur": {}, "raichu-alola": raichuAlola}
+
+	status := trainerDexStatus(configPtr, entries, pokedex)
+	if !status.caught[1] || !status.caught[25] || status.caught[4] {
+		t.Errorf("Expected bulbasaur and pikachu's species caught, Got %v", status.caught)
+	}
+	if !status.seen[1] || !status.seen[4] || !status.seen[152] {
+		t.Errorf("Expected caught species to count as seen, Got %v", status.seen)
+	}
+
+	progress := dexCompletion(entries, status)
+	gen1, gen2, total := progress[0], progress[1], progress[len(progress)-1]
+	if gen1.Seen != 3 || gen1.Caught != 2 || gen1.Total != 3 {
+		t.Errorf("Generation 1: Expected 3 seen, 2 caught of 3, Got %+v", gen1)
+	}
+	if gen2.Seen != 1 || gen2.Caught != 0 || gen2.Total != 1 {
+		t.Errorf("Generation 2: Expected 1 seen, 0 caught of 1, Got %+v", gen2)
+	}
+	// Species past the generation table still count toward the total.
+	if total.Label != "all" || total.Total != 5 || total.Seen != 4 || total.Caught != 2 {
+		t.Errorf("All: Expected 4 seen, 2 caught of 5, Got %+v", total)
+	}
+}
+
+func TestPercent(t *testing.T) {
+	cases := []struct {
+		part, total int
+		expected    string
+	}{
+		{part: 1, total: 3, expected: "33.3%"},
+		{part: 151, total: 151, expected: "100.0%"},
+		{part: 0, total: 0, expected: "0.0%"},
+	}
+	for _, c := range cases {
+		if actual := percent(c.part, c.total); actual != c.expected {
+			t.Errorf("percent(%v, %v) Expected %v, Got %v", c.part, c.total, c.expected, actual)
+		}
+	}
+}
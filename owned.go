@@ -0,0 +1,281 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
+	"github.com/fatih/color"
+)
+
+// defaultShinyOdds is the chance (1 in defaultShinyOdds) that a wild Pokémon is shiny,
+// unless the trainer picked other odds with the shiny command.
+const defaultShinyOdds = 4096
+
+// shinyOdds returns the shiny odds (1 in n) of the game.
+func (configPtr *config) shinyOdds() int {
+	if configPtr.ShinyOdds > 0 {
+		return configPtr.ShinyOdds
+	}
+	return defaultShinyOdds
+}
+
+// rollShiny reports whether a wild Pokémon turns out shiny, at 1 in odds.
+func rollShiny(odds int, rng *rand.Rand) bool {
+	return rng.Intn(odds) == 0
+}
+
+// ownedPokemon is one individual Pokémon the trainer has caught.
+// Species data is not copied; it is looked up in the pokedex by Species.
+type ownedPokemon struct {
+	ID         int            `json:"id"`       // Unique across everything the trainer has ever caught
+	Species    string         `json:"species"`  // Key into the pokedex map
+	Nickname   string         `json:"nickname"` // "" when the Pokémon has no nickname
+	Level      int            `json:"level"`
+	Nature     string         `json:"nature"`
+	IVs        map[string]int `json:"ivs"`    // Individual values per stat, 0-31
+	EVs        map[string]int `json:"evs"`    // Effort values per stat, starting at 0
+	Gender     string         `json:"gender"` // "male", "female" or "genderless"
+	Shiny      bool           `json:"shiny"`
+	CaughtArea string         `json:"caught_area"` // Location area it was caught in
+	CaughtAt   time.Time      `json:"caught_at"`
+	Damage     int            `json:"damage"`     // HP lost in battle; 0 means full health
+	Experience int            `json:"experience"` // Total experience points earned
+	Moves      []string       `json:"moves"`      // Up to four known moves
+}
+
+// displayName returns the nickname if there is one, otherwise the species name.
+func (o *ownedPokemon) displayName() string {
+	if o.Nickname != "" {
+		return o.Nickname
+	}
+	return o.Species
+}
+
+// maxShinyOdds is the longest shiny odds the shiny command accepts.
+const maxShinyOdds = 65536
+
+// commandShiny shows the shiny odds and the shiny Pokémon the trainer owns.
+// shiny <n> sets the odds to 1 in n, shiny default goes back to defaultShinyOdds.
+func commandShiny(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	if len(args) > 0 {
+		odds := 0
+		if args[0] != "default" {
+			parsed, err := strconv.Atoi(strings.TrimPrefix(args[0], "1/"))
+			if err != nil || parsed < 1 || parsed > maxShinyOdds {
+				color.New(color.FgHiRed, color.Bold).Printf("The odds must be a number from 1 to %d (1 in n), or default.\n", maxShinyOdds)
+				return nil
+			}
+			odds = parsed
+		}
+		if odds == defaultShinyOdds {
+			odds = 0
+		}
+		configPtr.ShinyOdds = odds
+		configPtr.unsaved = true
+		color.New(color.FgHiGreen, color.Bold).Printf("Wild Pokémon are now shiny 1 in %d times.\n", configPtr.shinyOdds())
+		return nil
+	}
+
+	color.New(color.FgCyan, color.Bold).Print("Shiny odds: ")
+	fmt.Printf("1 in %d", configPtr.shinyOdds())
+	if configPtr.ShinyOdds == 0 {
+		color.New(color.FgHiBlack).Print(" (default)")
+	}
+	fmt.Println()
+
+	shinies := []*ownedPokemon{}
+	for _, owned := range configPtr.allOwned() {
+		if owned.Shiny {
+			shinies = append(shinies, owned)
+		}
+	}
+	if len(shinies) == 0 {
+		color.New(color.FgHiBlack).Println("You don't own any shiny Pokémon yet.")
+		return nil
+	}
+	color.New(color.FgCyan, color.Bold).Printf("Your shiny Pokémon (%d):\n", len(shinies))
+	for i, owned := range shinies {
+		printOwnedLine(i+1, owned, pokedex)
+	}
+	return nil
+}
+
+// newOwnedPokemon rolls the individual data for a freshly caught Pokémon.
+// genderRate is the species' female chance in eighths, or -1 for genderless species.
+// Whether it is shiny was rolled when it appeared.
+func newOwnedPokemon(id int, species string, level int, genderRate int, shiny bool, area string, rng *rand.Rand) *ownedPokemon {
+	natureNames := internal.NatureNames()
+	owned := &ownedPokemon{
+		ID:         id,
+		Species:    species,
+		Level:      level,
+		Nature:     natureNames[rng.Intn(len(natureNames))],
+		IVs:        make(map[string]int),
+		EVs:        make(map[string]int),
+		Shiny:      shiny,
+		CaughtArea: area,
+		CaughtAt:   time.Now(),
+	}
+	for _, stat := range internal.StatNames {
+		owned.IVs[stat] = rng.Intn(internal.MaxIV + 1)
+		owned.EVs[stat] = 0
+	}
+
+	switch {
+	case genderRate < 0:
+		owned.Gender = "genderless"
+	case rng.Intn(8) < genderRate:
+		owned.Gender = "female"
+	default:
+		owned.Gender = "male"
+	}
+	return owned
+}
+
+// actualStats computes the Pokémon's stats from its species' base stats and its own IVs, EVs, level and nature.
+func (o *ownedPokemon) actualStats(details pokemonDetails) map[string]int {
+	stats := make(map[string]int)
+	for _, value := range details.Stats {
+		name := value.Stat.Name
+		stats[name] = internal.CalcStat(name, value.BaseStat, o.IVs[name], o.EVs[name], o.Level, o.Nature)
+	}
+	return stats
+}
+
+// currentHP returns the Pokémon's remaining HP.
+func (o *ownedPokemon) currentHP(details pokemonDetails) int {
+	hp := o.actualStats(details)["hp"] - o.Damage
+	if hp < 0 {
+		return 0
+	}
+	return hp
+}
+
+// findOwned looks up an owned Pokémon by ID ("12" or "#12"), by nickname,
+// or by species name when the trainer owns exactly one of that species.
+func findOwned(configPtr *config, ref string) (*ownedPokemon, bool) {
+	all := configPtr.allOwned()
+	if id, err := strconv.Atoi(strings.TrimPrefix(ref, "#")); err == nil {
+		for _, owned := range all {
+			if owned.ID == id {
+				return owned, true
+			}
+		}
+		return nil, false
+	}
+	for _, owned := range all {
+		if owned.Nickname != "" && owned.Nickname == ref {
+			return owned, true
+		}
+	}
+	if ofSpecies := ownedOfSpecies(configPtr, ref); len(ofSpecies) == 1 {
+		return ofSpecies[0], true
+	}
+	return nil, false
+}
+
+// ownedOfSpecies returns every owned Pokémon of the given species, party first.
+func ownedOfSpecies(configPtr *config, species string) []*ownedPokemon {
+	result := []*ownedPokemon{}
+	for _, owned := range configPtr.allOwned() {
+		if owned.Species == species {
+			result = append(result, owned)
+		}
+	}
+	return result
+}
+
+// encounterIs reports whether the rolled wild encounter is pokemon, or another form of
+// its species, so that catching vulpix-alola uses and ends a vulpix encounter.
+func (configPtr *config) encounterIs(pokemon pokemonDetails) bool {
+	if configPtr.Encounter == nil {
+		return false
+	}
+	name, species := configPtr.Encounter.Pokemon, pokemon.Species.Name
+	return name == pokemon.Name || (species != "" && (name == species || strings.HasPrefix(name, species+"-")))
+}
+
+// encounterShiny reports whether a Pokémon caught in the current area is shiny: the rolled
+// wild encounter already knows, any other Pokémon rolls at the game's shiny odds.
+func encounterShiny(configPtr *config, pokemon pokemonDetails, rng *rand.Rand) bool {
+	if configPtr.encounterIs(pokemon) {
+		return configPtr.Encounter.Shiny
+	}
+	return rollShiny(configPtr.shinyOdds(), rng)
+}
+
+// encounterLevel picks the level for a Pokémon caught in the current area.
+// It prefers the level of the rolled wild encounter, then the area's encounter slots, then 5.
+func encounterLevel(configPtr *config, areaDetails locationAreaDetails, pokemon pokemonDetails, rng *rand.Rand) int {
+	if configPtr.encounterIs(pokemon) {
+		return configPtr.Encounter.Level
+	}
+	pokemonName := pokemon.Name
+
+	minLevel, maxLevel := 0, 0
+	for _, encounter := range areaDetails.PokemonEncounters {
+		if encounter.Pokemon.Name != pokemonName {
+			continue
+		}
+		for _, versionDetail := range encounter.VersionDetails {
+			for _, detail := range versionDetail.EncounterDetails {
+				if minLevel == 0 || detail.MinLevel < minLevel {
+					minLevel = detail.MinLevel
+				}
+				if detail.MaxLevel > maxLevel {
+					maxLevel = detail.MaxLevel
+				}
+			}
+		}
+	}
+	if minLevel == 0 {
+		return 5
+	}
+	return minLevel + rng.Intn(maxLevel-minLevel+1)
+}
+
+// printOwned shows an owned Pokémon's individual data and its computed stats.
+func printOwned(owned *ownedPokemon, details pokemonDetails) {
+	header := color.New(color.FgHiYellow, color.Bold)
+	header.Printf("#%d %v", owned.ID, owned.displayName())
+	if owned.Nickname != "" {
+		header.Printf(" (%v)", owned.Species)
+	}
+	if owned.Shiny {
+		color.New(color.FgHiYellow, color.Bold).Print(" ★ shiny")
+	}
+	fmt.Println()
+
+	color.New(color.Bold).Printf("Level: %d\nNature: %v\nGender: %v\n", owned.Level, owned.Nature, owned.Gender)
+	color.New(color.Bold).Printf("Caught: %v on %v\n", owned.CaughtArea, owned.CaughtAt.Format("2006-01-02 15:04"))
+	color.New(color.Bold).Printf("HP: %d/%d\n", owned.currentHP(details), owned.actualStats(details)["hp"])
+	color.New(color.Bold).Printf("Exp. Points: %d\n", owned.Experience)
+	if len(owned.Moves) > 0 {
+		color.New(color.Bold).Printf("Moves: %v\n", strings.Join(owned.Moves, ", "))
+	}
+
+	color.New(color.FgCyan, color.Bold).Println("Stats (actual / base, IV, EV):")
+	stats := owned.actualStats(details)
+	raised, lowered := internal.NatureEffect(owned.Nature)
+	for _, value := range details.Stats {
+		name := value.Stat.Name
+		statColor := color.New(color.Bold)
+		marker := ""
+		switch name {
+		case raised:
+			statColor.Add(color.FgHiRed)
+			marker = " ↑"
+		case lowered:
+			statColor.Add(color.FgHiBlue)
+			marker = " ↓"
+		default:
+			statColor.Add(color.FgWhite)
+		}
+		statColor.Printf("  - %v: %d%v", name, stats[name], marker)
+		color.New(color.FgHiBlack).Printf("  (%d, %d, %d)\n", value.BaseStat, owned.IVs[name], owned.EVs[name])
+	}
+}
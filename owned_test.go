@@ -0,0 +1,164 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
+)
+
+// TestNewOwnedPokemon checks that rolled individual data stays within legal ranges.
+func TestNewOwnedPokemon(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+
+	for i := 0; i < 100; i++ {
+		owned := newOwnedPokemon(i, "magnemite", 10, -1, false, "route-1", rng)
+		if owned.Gender != "genderless" {
+			t.Errorf("Expected genderless, Got %v", owned.Gender)
+		}
+		if !internal.IsNature(owned.Nature) {
+			t.Errorf("Unknown nature %v", owned.Nature)
+		}
+		for _, stat := range internal.StatNames {
+			if owned.IVs[stat] < 0 || owned.IVs[stat] > internal.MaxIV {
+				t.Errorf("IV %v=%v out of range", stat, owned.IVs[stat])
+			}
+			if owned.EVs[stat] != 0 {
+				t.Errorf("Expected a fresh catch to have 0 EVs, Got %v=%v", stat, owned.EVs[stat])
+			}
+		}
+	}
+
+	// A gender rate of 8 eighths is always female.
+	if owned := newOwnedPokemon(1, "chansey", 10, 8, false, "", rng); owned.Gender != "female" {
+		t.Errorf("Expected female, Got %v", owned.Gender)
+	}
+}
+
+// TestFindOwned checks lookups by ID, by nickname and by unambiguous species.
+func TestFindOwned(t *testing.T) {
+	configPtr := &config{
+		Party: []*ownedPokemon{
+			{ID: 1, Species: "pikachu", Nickname: "sparky"},
+			{ID: 2, Species: "pikachu"},
+		},
+		Boxes: [][]*ownedPokemon{
+			{{ID: 3, Species: "onix"}},
+		},
+	}
+
+	cases := []struct {
+		ref        string
+		expectedID int // 0 means not found
+	}{
+		{ref: "#2", expectedID: 2},
+		{ref: "1", expectedID: 1},
+		{ref: "sparky", expectedID: 1},
+		{ref: "pikachu", expectedID: 0}, // Two pikachu, so the species is ambiguous
+		{ref: "onix", expectedID: 3},
+		{ref: "#9", expectedID: 0},
+	}
+
+	for _, c := range cases {
+		owned, ok := findOwned(configPtr, c.ref)
+		if c.expectedID == 0 {
+			if ok {
+				t.Errorf("%v: expected no match, Got #%v", c.ref, owned.ID)
+			}
+			continue
+		}
+		if !ok || owned.ID != c.expectedID {
+			t.Errorf("%v: Expected #%v, Got %v", c.ref, c.expectedID, owned)
+		}
+	}
+}
+
+// TestEncounterShiny checks the shiny odds and that a caught encounter keeps its roll.
+func TestEncounterShiny(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	configPtr := &config{}
+	if odds := configPtr.shinyOdds(); odds != defaultShinyOdds {
+		t.Errorf("Expected the default odds %v, Got %v", defaultShinyOdds, odds)
+	}
+
+	// At 1 in 1 every roll is shiny, unless the encounter already rolled otherwise.
+	configPtr.ShinyOdds = 1
+	if !encounterShiny(configPtr, pokemonDetails{Name: "pidgey"}, rng) {
+		t.Errorf("Expected a shiny pidgey at 1 in 1 odds")
+	}
+	configPtr.Encounter = &wildEncounter{Pokemon: "pidgey", Shiny: false}
+	if encounterShiny(configPtr, pokemonDetails{Name: "pidgey"}, rng) {
+		t.Errorf("Expected the encounter's roll to be kept")
+	}
+	configPtr.Encounter = &wildEncounter{Pokemon: "rattata", Shiny: true}
+	configPtr.ShinyOdds = maxShinyOdds
+	shinies := 0
+	for i := 0; i < 100; i++ {
+		if encounterShiny(configPtr, pokemonDetails{Name: "rattata"}, rng) {
+			shinies++
+		}
+	}
+	if shinies != 100 {
+		t.Errorf("Expected the shiny encounter to stay shiny, Got %d of 100", shinies)
+	}
+}
+
+// TestEncounterIs checks that a form of the encountered species matches the encounter.
+func TestEncounterIs(t *testing.T) {
+	vulpixAlola := pokemonDetails{Name: "vulpix-alola"}
+	vulpixAlola.Species.Name = "vulpix"
+	pidgey := pokemonDetails{Name: "pidgey"}
+	pidgey.Species.Name = "pidgey"
+
+	cases := []struct {
+		encounter *wildEncounter
+		pokemon   pokemonDetails
+		expected  bool
+	}{
+		{encounter: nil, pokemon: pidgey, expected: false},
+		{encounter: &wildEncounter{Pokemon: "pidgey"}, pokemon: pidgey, expected: true},
+		{encounter: &wildEncounter{Pokemon: "vulpix"}, pokemon: vulpixAlola, expected: true},
+		{encounter: &wildEncounter{Pokemon: "vulpix-galar"}, pokemon: vulpixAlola, expected: true},
+		{encounter: &wildEncounter{Pokemon: "pidgeotto"}, pokemon: pidgey, expected: false},
+	}
+	for _, c := range cases {
+		configPtr := &config{Encounter: c.encounter}
+		if actual := configPtr.encounterIs(c.pokemon); actual != c.expected {
+			t.Errorf("%v against %+v: Expected %v, Got %v", c.pokemon.Name, c.encounter, c.expected, actual)
+		}
+	}
+}
+
+// TestThrowPokeballFullPC checks that a catch with nowhere to go leaves no trace:
+// no Pokédex entry, no used ID and no counted catch.
+func TestThrowPokeballFullPC(t *testing.T) {
+	cachePtr := internal.NewCache(time.Minute)
+	cachePtr.Add("https://pokeapi.co/api/v2/pokemon-species/pidgey/",
+		[]byte(`{"name": "pidgey", "gender_rate": 4, "growth_rate": {"name": "medium-slow", "url": "https://pokeapi.co/api/v2/growth-rate/4/"}}`))
+	cachePtr.Add("https://pokeapi.co/api/v2/growth-rate/4/", []byte(`{"levels": [{"level": 1, "experience": 0}]}`))
+
+	configPtr := &config{NextID: 7}
+	for len(configPtr.Party) < maxPartySize {
+		configPtr.Party = append(configPtr.Party, &ownedPokemon{Species: "rattata"})
+	}
+	for configPtr.boxWithRoom() != -1 {
+		box := configPtr.boxWithRoom()
+		configPtr.Boxes[box] = append(configPtr.Boxes[box], &ownedPokemon{Species: "rattata"})
+	}
+	pidgey := pokemonDetails{Name: "pidgey"}
+	pidgey.Species.Name = "pidgey"
+	pokedex := map[string]pokemonDetails{}
+
+	// Each throw catches 99 times in 100; a miss just means throwing again.
+	var err error
+	for i := 0; i < 20 && err == nil; i++ {
+		_, _, err = throwPokeball(configPtr, cachePtr, pidgey, locationAreaDetails{}, pokedex)
+	}
+	if err == nil {
+		t.Fatalf("Expected the catch to fail with every PC box full")
+	}
+	if _, ok := pokedex["pidgey"]; ok || configPtr.NextID != 7 || configPtr.Trainer.Catches != 0 {
+		t.Errorf("Expected no pidgey, ID 7 and no catches, Got %v, %d and %d", pokedex, configPtr.NextID, configPtr.Trainer.Catches)
+	}
+}
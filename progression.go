@@ -0,0 +1,276 @@
+package main
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
+	"github.com/fatih/color"
+)
+
+// growthRate holds the experience curve from the PokeAPI growth-rate endpoint.
+type growthRate struct {
+	Name   string `json:"name"` // e.g. "medium-slow"
+	Levels []struct {
+		Level      int `json:"level"`
+		Experience int `json:"experience"` // Total experience needed to reach Level
+	} `json:"levels"`
+}
+
+// experienceForLevel returns the total experience needed to reach a level on this curve.
+func (rate growthRate) experienceForLevel(level int) int {
+	for _, step := range rate.Levels {
+		if step.Level == level {
+			return step.Experience
+		}
+	}
+	return 0
+}
+
+// levelForExperience returns the highest level reached with the given total experience.
+func (rate growthRate) levelForExperience(experience int) int {
+	level := 1
+	for _, step := range rate.Levels {
+		if experience >= step.Experience && step.Level > level {
+			level = step.Level
+		}
+	}
+	return level
+}
+
+// fetchGrowthRate looks up the experience curve of a Pokémon's species.
+func fetchGrowthRate(cachePtr *internal.Cache, speciesName string) (growthRate, error) {
+	var rate growthRate
+
+	species, err := fetchSpecies(cachePtr, speciesName)
+	if err != nil {
+		return rate, err
+	}
+	val, err := fetchData(cachePtr, species.GrowthRate.URL)
+	if err != nil {
+		return rate, err
+	}
+	err = json.Unmarshal(val, &rate)
+	return rate, err
+}
+
+// learnVersionGroup picks the version group whose learnset applies to a Pokémon:
+// the preferred one if the Pokémon learns moves by level-up in it, otherwise the newest one it has.
+func learnVersionGroup(details pokemonDetails, preferred string) string {
+	newest, newestID := "", 0
+	for _, move := range details.Moves {
+		for _, versionGroup := range move.VersionGroupDetails {
+			if versionGroup.MoveLearnMethod.Name != "level-up" {
+				continue
+			}
+			if versionGroup.VersionGroup.Name == preferred {
+				return preferred
+			}
+			if id := idFromURL(versionGroup.VersionGroup.URL); id > newestID {
+				newest, newestID = versionGroup.VersionGroup.Name, id
+			}
+		}
+	}
+	return newest
+}
+
+// levelUpMoves returns the moves learned by level-up in a version group
+// at a level above fromLevel and up to toLevel, ordered by level.
+func levelUpMoves(details pokemonDetails, versionGroup string, fromLevel, toLevel int) []string {
+	learnedAt := make(map[string]int)
+	for _, move := range details.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			if detail.MoveLearnMethod.Name != "level-up" || detail.VersionGroup.Name != versionGroup {
+				continue
+			}
+			if detail.LevelLearnedAt > fromLevel && detail.LevelLearnedAt <= toLevel {
+				learnedAt[move.Move.Name] = detail.LevelLearnedAt
+			}
+		}
+	}
+
+	names := make([]string, 0, len(learnedAt))
+	for name := range learnedAt {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if learnedAt[names[i]] != learnedAt[names[j]] {
+			return learnedAt[names[i]] < learnedAt[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// initProgress sets a freshly caught Pokémon's experience to match its level
+// and gives it the moves it would know at that level.
+func initProgress(cachePtr *internal.Cache, owned *ownedPokemon, details pokemonDetails, versionGroup string) error {
+	rate, err := fetchGrowthRate(cachePtr, details.Species.Name)
+	if err != nil {
+		return err
+	}
+	owned.Experience = rate.experienceForLevel(owned.Level)
+	owned.Moves = defaultMoveset(details, owned.Level, learnVersionGroup(details, versionGroup))
+	return nil
+}
+
+// gainExperience awards experience and effort values for defeating a wild Pokémon,
+// leveling up and learning new moves as thresholds are crossed.
+func gainExperience(configPtr *config, cachePtr *internal.Cache, owned *ownedPokemon, details pokemonDetails, defeated *battler) error {
+	rate, err := fetchGrowthRate(cachePtr, details.Species.Name)
+	if err != nil {
+		return err
+	}
+	// Pokémon from older saves may not have their experience set yet.
+	if minimum := rate.experienceForLevel(owned.Level); owned.Experience < minimum {
+		owned.Experience = minimum
+	}
+
+	gain := internal.ExperienceGain(defeated.details.BaseExperience, defeated.pokemon.Level)
+	owned.Experience += gain
+	color.New(color.FgHiCyan, color.Bold).Printf("%v gained %d Exp. Points!\n", owned.displayName(), gain)
+
+	effort := make(map[string]int)
+	for _, stat := range defeated.details.Stats {
+		effort[stat.Stat.Name] = stat.Effort
+	}
+	internal.AddEVs(owned.EVs, effort)
+
+	newLevel := rate.levelForExperience(owned.Experience)
+	if newLevel > internal.MaxLevel {
+		newLevel = internal.MaxLevel
+	}
+	if newLevel <= owned.Level {
+		configPtr.unsaved = true
+		return nil
+	}
+
+	oldLevel := owned.Level
+	oldMaxHP := owned.actualStats(details)["hp"]
+	owned.Level = newLevel
+	// Leveling up raises max HP; the Pokémon keeps the damage it had taken.
+	color.New(color.FgHiGreen, color.Bold).Printf("%v grew to Lv. %d! (max HP %d -> %d)\n",
+		owned.displayName(), newLevel, oldMaxHP, owned.actualStats(details)["hp"])
+
+	versionGroup := learnVersionGroup(details, configPtr.VersionGroup)
+	for _, move := range levelUpMoves(details, versionGroup, oldLevel, newLevel) {
+		learnMove(owned, move)
+	}
+	configPtr.unsaved = true
+
+	// Reaching a new level may be what it takes to evolve.
+	_, err = checkEvolution(configPtr, cachePtr, owned, details, "level-up", "")
+	return err
+}
+
+// knowsMove reports whether an owned Pokémon's moveset contains a move.
+func knowsMove(owned *ownedPokemon, move string) bool {
+	for _, known := range owned.Moves {
+		if known == move {
+			return true
+		}
+	}
+	return false
+}
+
+// learnMove teaches a move if there is room in the four-move moveset,
+// otherwise it tells the trainer how to make room with teach.
+func learnMove(owned *ownedPokemon, move string) {
+	if knowsMove(owned, move) {
+		return
+	}
+	if len(owned.Moves) < 4 {
+		owned.Moves = append(owned.Moves, move)
+		color.New(color.FgHiGreen, color.Bold).Printf("%v learned %v!\n", owned.displayName(), move)
+		return
+	}
+	color.New(color.FgHiYellow, color.Bold).Printf("%v wants to learn %v, but already knows four moves.\n", owned.displayName(), move)
+	color.New(color.FgCyan).Printf("Use: teach %v %v <move to forget>\n", owned.displayName(), move)
+}
+
+// moves shows an owned Pokémon's moveset and the level-up moves it can still be taught.
+func moves(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	if len(args) == 0 {
+		color.New(color.FgHiRed, color.Bold).Println("Error: missing pokemon argument.")
+		return nil
+	}
+	owned, ok := lookupOwned(configPtr, args[0])
+	if !ok {
+		return nil
+	}
+	details := pokedex[owned.Species]
+
+	color.New(color.FgCyan, color.Bold).Printf("%v's moves:\n", owned.displayName())
+	if len(owned.Moves) == 0 {
+		color.New(color.FgHiBlack).Println("  (none yet)")
+	}
+	for i, move := range owned.Moves {
+		color.New(color.Bold).Printf("  %d. %v\n", i+1, move)
+	}
+
+	versionGroup := learnVersionGroup(details, configPtr.VersionGroup)
+	teachable := []string{}
+	for _, move := range levelUpMoves(details, versionGroup, 0, owned.Level) {
+		if !knowsMove(owned, move) {
+			teachable = append(teachable, move)
+		}
+	}
+	if len(teachable) > 0 {
+		color.New(color.FgCyan, color.Bold).Printf("Can also know (%v, up to Lv. %d):\n", versionGroup, owned.Level)
+		color.New(color.FgHiBlack).Printf("  %v\n", strings.Join(teachable, ", "))
+	}
+	return nil
+}
+
+// teach swaps a move into an owned Pokémon's moveset. The move must be one it
+// learns by level-up at or below its level; a full moveset needs a move to forget.
+func teach(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	if len(args) < 2 {
+		color.New(color.FgHiRed, color.Bold).Println("Error: usage is teach <pokemon> <move> [move to forget].")
+		return nil
+	}
+	if busyInBattle(configPtr) {
+		return nil
+	}
+	owned, ok := lookupOwned(configPtr, args[0])
+	if !ok {
+		return nil
+	}
+	move := args[1]
+	details := pokedex[owned.Species]
+
+	versionGroup := learnVersionGroup(details, configPtr.VersionGroup)
+	learnable := false
+	for _, candidate := range levelUpMoves(details, versionGroup, 0, owned.Level) {
+		if candidate == move {
+			learnable = true
+		}
+	}
+	if !learnable {
+		color.New(color.FgHiRed, color.Bold).Printf("%v can't learn %v at Lv. %d.\n", owned.displayName(), move, owned.Level)
+		return nil
+	}
+	if knowsMove(owned, move) {
+		color.New(color.FgHiRed, color.Bold).Printf("%v already knows %v.\n", owned.displayName(), move)
+		return nil
+	}
+
+	if len(owned.Moves) < 4 {
+		learnMove(owned, move)
+		configPtr.unsaved = true
+		return nil
+	}
+	if len(args) < 3 || !knowsMove(owned, args[2]) {
+		color.New(color.FgHiRed, color.Bold).Printf("%v already knows four moves; name one of them to forget.\n", owned.displayName())
+		return nil
+	}
+	for i, known := range owned.Moves {
+		if known == args[2] {
+			owned.Moves[i] = move
+		}
+	}
+	configPtr.unsaved = true
+	color.New(color.FgHiGreen, color.Bold).Printf("1, 2 and... Poof! %v forgot %v and learned %v!\n", owned.displayName(), args[2], move)
+	return nil
+}
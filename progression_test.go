@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestGrowthRate checks converting between levels and total experience.
+func TestGrowthRate(t *testing.T) {
+	var rate growthRate
+	data := `{"name": "test", "levels": [
+		{"level": 1, "experience": 0},
+		{"level": 2, "experience": 10},
+		{"level": 3, "experience": 30},
+		{"level": 4, "experience": 60}
+	]}`
+	if err := json.Unmarshal([]byte(data), &rate); err != nil {
+		t.Fatalf("could not parse growth rate: %v", err)
+	}
+
+	cases := []struct {
+		experience int
+		expected   int
+	}{
+		{experience: 0, expected: 1},
+		{experience: 29, expected: 2},
+		{experience: 30, expected: 3},
+		{experience: 1000, expected: 4},
+	}
+	for _, c := range cases {
+		if level := rate.levelForExperience(c.experience); level != c.expected {
+			t.Errorf("%v exp: Expected Lv. %v, Got Lv. %v", c.experience, c.expected, level)
+		}
+	}
+	if experience := rate.experienceForLevel(3); experience != 30 {
+		t.Errorf("Expected 30 exp for Lv. 3, Got %v", experience)
+	}
+}
+
+// TestLevelUpMoves checks the moves learned between two levels and the version group fallback.
+func TestLevelUpMoves(t *testing.T) {
+	var details pokemonDetails
+	if err := json.Unmarshal([]byte(testPokemonMoves), &details); err != nil {
+		t.Fatalf("could not parse test pokemon: %v", err)
+	}
+
+	learned := levelUpMoves(details, "red-blue", 8, 16)
+	if len(learned) != 2 || learned[0] != "thunder-wave" || learned[1] != "quick-attack" {
+		t.Errorf("Expected [thunder-wave quick-attack], Got %v", learned)
+	}
+
+	if group := learnVersionGroup(details, "x-y"); group != "x-y" {
+		t.Errorf("Expected the preferred version group x-y, Got %v", group)
+	}
+	// sword-shield has no level-up moves, so the newest version group is used instead.
+	if group := learnVersionGroup(details, "sword-shield"); group != "x-y" {
+		t.Errorf("Expected the fallback version group x-y, Got %v", group)
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
+	"github.com/fatih/color"
+)
+
+// regions lists every region, marking the ones of the selected game.
+func regions(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	list, err := fetchNamedList(cachePtr, "region")
+	if err != nil {
+		return err
+	}
+
+	inGame := make(map[string]bool)
+	if configPtr.VersionGroup != "" {
+		var group versionGroupDetails
+		if err := fetchResource(cachePtr, "version-group", configPtr.VersionGroup, &group); err != nil {
+			return err
+		}
+		for _, r := range group.Regions {
+			inGame[r.Name] = true
+		}
+	}
+
+	color.New(color.FgCyan, color.Bold).Println("Regions:")
+	for _, result := range list.Results {
+		color.New(color.FgHiGreen, color.Bold).Printf(" - %v", result.Name)
+		if inGame[result.Name] {
+			color.New(color.FgHiYellow).Printf("  (pokemon %v)", configPtr.Version)
+		}
+		fmt.Println()
+	}
+	color.New(color.FgHiBlack).Println("Use region <name> to see its locations.")
+	return nil
+}
+
+// region lists the locations of a region in the PokeAPI's order, which follows the games' numbering.
+func region(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	if len(args) == 0 {
+		color.New(color.FgHiRed, color.Bold).Println("Error: missing region argument.")
+		return nil
+	}
+	var details regionDetails
+	if err := fetchResource(cachePtr, "region", args[0], &details); err != nil {
+		return err
+	}
+
+	games := []string{}
+	for _, group := range details.VersionGroups {
+		games = append(games, group.Name)
+	}
+	color.New(color.FgHiYellow, color.Bold).Printf("Region: %v\n", details.Name)
+	color.New(color.Bold).Printf("Generation: %v\n", details.MainGeneration.Name)
+	if len(games) > 0 {
+		color.New(color.Bold).Printf("Games: %v\n", strings.Join(games, ", "))
+	}
+
+	color.New(color.FgCyan, color.Bold).Printf("Locations (%d):\n", len(details.Locations))
+	for i, location := range details.Locations {
+		color.New(color.FgHiGreen, color.Bold).Printf(" %3d. %v\n", i+1, location.Name)
+	}
+	color.New(color.FgHiBlack).Println("Use location <name> to see its areas.")
+	return nil
+}
+
+// location lists the areas of a location, which are what explore and travel take.
+func location(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	if len(args) == 0 {
+		color.New(color.FgHiRed, color.Bold).Println("Error: missing location argument.")
+		return nil
+	}
+	var details locationDetails
+	if err := fetchResource(cachePtr, "location", args[0], &details); err != nil {
+		return err
+	}
+
+	color.New(color.FgHiYellow, color.Bold).Printf("Location: %v\n", details.Name)
+	color.New(color.Bold).Printf("Region: %v\n", details.Region.Name)
+	if len(details.Areas) == 0 {
+		color.New(color.FgHiBlack).Println("There are no areas with wild Pokémon here.")
+		return nil
+	}
+
+	color.New(color.FgCyan, color.Bold).Println("Areas:")
+	for _, area := range details.Areas {
+		color.New(color.FgHiGreen, color.Bold).Printf(" - %v", area.Name)
+		if area.Name == configPtr.CurrentArea {
+			color.New(color.FgHiYellow).Print("  (you are here)")
+		}
+		fmt.Println()
+	}
+	color.New(color.FgHiBlack).Println("Use explore <area> or travel <area> to go there.")
+	return nil
+}
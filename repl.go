@@ -1,7 +1,12 @@
 package main
 
 import (
+	"fmt"
 	"strings"
+	"time"
+
+	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
+	"github.com/fatih/color"
 )
 
 // cleanInput takes a raw input string and returns a slice of cleaned words
@@ -13,3 +18,97 @@ func cleanInput(text string) []string {
 	words := strings.Fields(lower)
 	return words
 }
+
+// runInput handles one line of user input: it answers a pending yes/no question
+// or dispatches a command, then saves the game if the command changed it.
+func runInput(configPtr *config, cachePtr *internal.Cache, pokedex map[string]pokemonDetails, savePath string, userInput string) {
+	cleanedWords := cleanInput(userInput)
+	configPtr.typed = strings.Fields(userInput)
+	configPtr.tickPlayTime(time.Now())
+
+	if configPtr.Evolution != nil {
+		// A yes/no question is pending; nothing else happens until it is answered.
+		answered, err := answerPrompt(configPtr, cachePtr, pokedex, cleanedWords)
+		if err != nil {
+			fmt.Printf("Error occurred: %v\n", err)
+		} else if !answered {
+			color.New(color.FgHiYellow, color.Bold).Println("Please answer yes or no.")
+		}
+	} else if len(cleanedWords) > 0 {
+		command, exists := commandsMap[cleanedWords[0]]
+		if exists {
+			if command.name == "exit" {
+				// exit ends the program before the save below.
+				savePlayTime(savePath, configPtr, pokedex)
+			}
+			// Everything after the command name is handed to the callback as arguments.
+			err := command.callback(configPtr, cachePtr, cleanedWords[1:], pokedex)
+			if err != nil {
+				fmt.Printf("Error occurred: %v\n", err)
+			}
+		} else {
+			fmt.Println("Unknown command")
+		}
+	}
+
+	announceAchievements(checkAchievements(configPtr, pokedex, time.Now()))
+
+	// Persist the game whenever a command changed it.
+	if configPtr.unsaved {
+		if err := writeSave(savePath, configPtr, pokedex); err != nil {
+			fmt.Printf("Error saving game: %v\n", err)
+		} else {
+			configPtr.unsaved = false
+		}
+	}
+}
+
+// parseFlags splits command arguments into positional arguments and --flags.
+// A flag takes the following word as its value unless it is listed in boolFlags
+// or written as --flag=value. Boolean flags are stored with an empty value.
+func parseFlags(args []string, boolFlags ...string) ([]string, map[string]string) {
+	positional := []string{}
+	flags := make(map[string]string)
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "--") || len(arg) == 2 {
+			positional = append(positional, arg)
+			continue
+		}
+
+		name := strings.TrimPrefix(arg, "--")
+		// --flag=value form
+		if key, value, found := strings.Cut(name, "="); found {
+			flags[key] = value
+			continue
+		}
+
+		isBool := false
+		for _, boolFlag := range boolFlags {
+			if boolFlag == name {
+				isBool = true
+				break
+			}
+		}
+		// --flag value form, as long as the next word isn't another flag
+		if !isBool && i+1 < len(args) && !strings.HasPrefix(args[i+1], "--") {
+			flags[name] = args[i+1]
+			i++
+			continue
+		}
+		flags[name] = ""
+	}
+	return positional, flags
+}
+
+// originalWord returns word the way the user typed it, for arguments where case
+// matters such as file paths. typed holds the input split on whitespace.
+func originalWord(typed []string, word string) string {
+	for _, original := range typed {
+		if strings.ToLower(original) == word {
+			return original
+		}
+	}
+	return word
+}
@@ -49,3 +49,67 @@ func TestCleanInput(t *testing.T) {
 }
 
 
+// TestParseFlags checks that positional arguments and flags are separated,
+// and that boolean flags never swallow the word after them.
+func TestParseFlags(t *testing.T) {
+	cases := []struct {
+		input              []string
+		boolFlags          []string
+		expectedPositional []string
+		expectedFlags      map[string]string
+	}{
+		{
+			// Test case 1: plain positional argument
+			input:              []string{"pikachu"},
+			expectedPositional: []string{"pikachu"},
+			expectedFlags:      map[string]string{},
+		},
+		{
+			// Test case 2: boolean flag before the positional argument
+			input:              []string{"--cheat", "mewtwo"},
+			boolFlags:          []string{"cheat"},
+			expectedPositional: []string{"mewtwo"},
+			expectedFlags:      map[string]string{"cheat": ""},
+		},
+		{
+			// Test case 3: value flags in both supported forms
+			input:              []string{"bulbasaur", "--version-group", "red-blue", "--method=egg"},
+			expectedPositional: []string{"bulbasaur"},
+			expectedFlags:      map[string]string{"version-group": "red-blue", "method": "egg"},
+		},
+	}
+
+	for _, c := range cases {
+		positional, flags := parseFlags(c.input, c.boolFlags...)
+
+		if len(positional) != len(c.expectedPositional) {
+			t.Errorf("Expected positional: %v, Got positional: %v", c.expectedPositional, positional)
+			continue
+		}
+		for i := range positional {
+			if positional[i] != c.expectedPositional[i] {
+				t.Errorf("Expected positional: %v, Got positional: %v", c.expectedPositional, positional)
+			}
+		}
+
+		if len(flags) != len(c.expectedFlags) {
+			t.Errorf("Expected flags: %v, Got flags: %v", c.expectedFlags, flags)
+		}
+		for key, expectedValue := range c.expectedFlags {
+			if value, ok := flags[key]; !ok || value != expectedValue {
+				t.Errorf("Expected flag %s=%q, Got %q", key, expectedValue, value)
+			}
+		}
+	}
+}
+
+// TestOriginalWord checks that arguments can be recovered as they were typed.
+func TestOriginalWord(t *testing.T) {
+	typed := []string{"team", "import", "Teams/OU.txt"}
+	if actual := originalWord(typed, "teams/ou.txt"); actual != "Teams/OU.txt" {
+		t.Errorf("Expected Teams/OU.txt, Got %v", actual)
+	}
+	if actual := originalWord(typed, "missing"); actual != "missing" {
+		t.Errorf("Expected missing, Got %v", actual)
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// saveData is everything about a trainer's game that outlives a session.
+type saveData struct {
+	CurrentArea  string                    `json:"current_area"`
+	Version      string                    `json:"version"`
+	VersionGroup string                    `json:"version_group"`
+	NextID       int                       `json:"next_id"`
+	Party        []*ownedPokemon           `json:"party"`
+	Boxes        [][]*ownedPokemon         `json:"boxes"`
+	Seen         map[string]bool           `json:"seen"`
+	Team         []*teamMember             `json:"team"`
+	Trainer      trainerProfile            `json:"trainer"`
+	ShinyOdds    int                       `json:"shiny_odds,omitempty"`
+	Pokedex      map[string]pokemonDetails `json:"pokedex"` // Species data for every species ever caught
+}
+
+// defaultSavePath returns the save file location in the user's home directory,
+// falling back to the working directory when there is no home directory.
+func defaultSavePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "pokedexcli-save.json"
+	}
+	return filepath.Join(home, ".pokedexcli", "save.json")
+}
+
+// loadSave restores a saved game into the config and pokedex.
+// A missing save file is not an error; it just means a new game.
+func loadSave(path string, configPtr *config, pokedex map[string]pokemonDetails) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var save saveData
+	if err := json.Unmarshal(data, &save); err != nil {
+		return err
+	}
+
+	configPtr.CurrentArea = save.CurrentArea
+	configPtr.Version = save.Version
+	configPtr.VersionGroup = save.VersionGroup
+	configPtr.NextID = save.NextID
+	configPtr.Party = save.Party
+	configPtr.Boxes = save.Boxes
+	configPtr.Seen = save.Seen
+	configPtr.Team = save.Team
+	configPtr.Trainer = save.Trainer
+	configPtr.ShinyOdds = save.ShinyOdds
+	for name, details := range save.Pokedex {
+		pokedex[name] = details
+	}
+	return nil
+}
+
+// writeSave stores the game to disk. It writes to a temporary file first
+// so that a crash halfway through never leaves a corrupt save behind.
+func writeSave(path string, configPtr *config, pokedex map[string]pokemonDetails) error {
+	save := saveData{
+		CurrentArea:  configPtr.CurrentArea,
+		Version:      configPtr.Version,
+		VersionGroup: configPtr.VersionGroup,
+		NextID:       configPtr.NextID,
+		Party:        configPtr.Party,
+		Boxes:        configPtr.Boxes,
+		Seen:         configPtr.Seen,
+		Team:         configPtr.Team,
+		Trainer:      configPtr.Trainer,
+		ShinyOdds:    configPtr.ShinyOdds,
+		Pokedex:      pokedex,
+	}
+	data, err := json.Marshal(save)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+	configPtr.playTimeDue = false
+	return nil
+}
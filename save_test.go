@@ -0,0 +1,48 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// TestSaveRoundTrip checks that a written save restores the same game state.
+func TestSaveRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "save.json")
+
+	original := &config{
+		CurrentArea: "viridian-forest-area",
+		NextID:      2,
+		Party:       []*ownedPokemon{{ID: 1, Species: "caterpie", Nickname: "cat", Level: 3}},
+		Boxes:       [][]*ownedPokemon{{{ID: 2, Species: "weedle", Level: 4}}},
+		Seen:        map[string]bool{"caterpie": true, "weedle": true, "pikachu": true},
+	}
+	originalDex := map[string]pokemonDetails{"caterpie": {Name: "caterpie", ID: 10}}
+
+	if err := writeSave(path, original, originalDex); err != nil {
+		t.Fatalf("could not write save: %v", err)
+	}
+
+	restored := &config{}
+	restoredDex := make(map[string]pokemonDetails)
+	if err := loadSave(path, restored, restoredDex); err != nil {
+		t.Fatalf("could not load save: %v", err)
+	}
+
+	if restored.CurrentArea != original.CurrentArea || restored.NextID != original.NextID {
+		t.Errorf("Expected area %v and next ID %v, Got %v and %v", original.CurrentArea, original.NextID, restored.CurrentArea, restored.NextID)
+	}
+	if len(restored.Party) != 1 || restored.Party[0].Nickname != "cat" {
+		t.Errorf("party was not restored: %v", restored.Party)
+	}
+	if len(restored.Boxes) != 1 || restored.Boxes[0][0].Species != "weedle" {
+		t.Errorf("boxes were not restored: %v", restored.Boxes)
+	}
+	if len(restored.Seen) != 3 || restoredDex["caterpie"].ID != 10 {
+		t.Errorf("pokedex was not restored: seen %v, dex %v", restored.Seen, restoredDex)
+	}
+
+	// A missing save file just means a new game.
+	if err := loadSave(filepath.Join(t.TempDir(), "missing.json"), &config{}, restoredDex); err != nil {
+		t.Errorf("expected no error for a missing save, Got %v", err)
+	}
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
+	"github.com/fatih/color"
+)
+
+// shutdownTimeout is how long serve waits for requests in flight when it is stopped.
+const shutdownTimeout = 5 * time.Second
+
+// apiServer serves the game over HTTP with the same data and save file as the REPL.
+// Handlers run concurrently, so all game state is accessed under mu.
+type apiServer struct {
+	mu        sync.Mutex
+	configPtr *config
+	cachePtr  *internal.Cache
+	pokedex   map[string]pokemonDetails
+	savePath  string // "" to keep changes in memory only
+}
+
+// apiError is the body of every error response.
+type apiError struct {
+	Error string `json:"error"`
+}
+
+// writeJSON writes v as a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes a JSON error response.
+func writeError(w http.ResponseWriter, status int, format string, args ...any) {
+	writeJSON(w, status, apiError{Error: fmt.Sprintf(format, args...)})
+}
+
+// errorStatus picks the status code for an error from fetching PokeAPI data:
+// names the PokeAPI doesn't know are not found, anything else is its failure.
+func errorStatus(err error) int {
+	if isNotFound(err) {
+		return http.StatusNotFound
+	}
+	return http.StatusBadGateway
+}
+
+// routes returns the handler of every endpoint.
+func (s *apiServer) routes() http.Handler {
+	endpoints := []struct {
+		method  string
+		path    string
+		handler http.HandlerFunc
+	}{
+		{method: "GET", path: "/pokedex", handler: s.handlePokedex},
+		{method: "GET", path: "/pokemon/{name}", handler: s.handlePokemon},
+		{method: "POST", path: "/catch", handler: s.handleCatch},
+		{method: "GET", path: "/locations", handler: s.handleLocations},
+	}
+
+	mux := http.NewServeMux()
+	for _, endpoint := range endpoints {
+		mux.HandleFunc(endpoint.method+" "+endpoint.path, endpoint.handler)
+		// Without a method the path matches every other method, which the endpoint doesn't allow.
+		mux.HandleFunc(endpoint.path, methodNotAllowed(endpoint.method))
+	}
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "no endpoint %v %v", r.Method, r.URL.Path)
+	})
+	return mux
+}
+
+// methodNotAllowed answers a request to an endpoint that only allows method.
+func methodNotAllowed(method string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Allow", method)
+		writeError(w, http.StatusMethodNotAllowed, "%v %v is not allowed; use %v", r.Method, r.URL.Path, method)
+	}
+}
+
+// save writes the game to the save file if a request changed it.
+func (s *apiServer) save() error {
+	if !s.configPtr.unsaved || s.savePath == "" {
+		return nil
+	}
+	if err := writeSave(s.savePath, s.configPtr, s.pokedex); err != nil {
+		return err
+	}
+	s.configPtr.unsaved = false
+	return nil
+}
+
+// handlePokedex serves GET /pokedex: caught species in dex order, seen species and owned Pokémon.
+func (s *apiServer) handlePokedex(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen := []string{}
+	for name := range s.configPtr.Seen {
+		seen = append(seen, name)
+	}
+	sort.Strings(seen)
+	writeJSON(w, http.StatusOK, struct {
+		Caught []dexRow        `json:"caught"`
+		Seen   []string        `json:"seen"`
+		Owned  []*ownedPokemon `json:"owned"`
+	}{
+		Caught: dexRows(s.configPtr, s.pokedex),
+		Seen:   seen,
+		Owned:  s.configPtr.allOwned(),
+	})
+}
+
+// handlePokemon serves GET /pokemon/{name}: the PokeAPI data of any Pokémon,
+// with the owned individuals of that species.
+func (s *apiServer) handlePokemon(w http.ResponseWriter, r *http.Request) {
+	name := internal.NormalizeName(r.PathValue("name"))
+
+	s.mu.Lock()
+	details, caught := s.pokedex[name]
+	owned := ownedOfSpecies(s.configPtr, name)
+	s.mu.Unlock()
+
+	if !caught {
+		var err error
+		details, err = fetchPokemon(s.cachePtr, name)
+		if err != nil {
+			writeError(w, errorStatus(err), "can't get pokemon %v: %v", name, err)
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, struct {
+		Caught  bool            `json:"caught"`
+		Owned   []*ownedPokemon `json:"owned"`
+		Pokemon pokemonDetails  `json:"pokemon"`
+	}{Caught: caught, Owned: owned, Pokemon: details})
+}
+
+// catchRequest is the body of POST /catch. Area travels there first;
+// without it the Pokémon has to live in the current area.
+type catchRequest struct {
+	Pokemon string `json:"pokemon"`
+	Area    string `json:"area"`
+}
+
+// handleCatch serves POST /catch: one Pokéball thrown at a wild Pokémon, as with catch.
+func (s *apiServer) handleCatch(w http.ResponseWriter, r *http.Request) {
+	var request catchRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: %v", err)
+		return
+	}
+	request.Pokemon = internal.NormalizeName(request.Pokemon)
+	request.Area = internal.NormalizeName(request.Area)
+	if request.Pokemon == "" {
+		writeError(w, http.StatusBadRequest, "missing pokemon")
+		return
+	}
+
+	s.mu.Lock()
+	area, version := request.Area, s.configPtr.Version
+	if area == "" {
+		area = s.configPtr.CurrentArea
+	}
+	s.mu.Unlock()
+	if area == "" {
+		writeError(w, http.StatusBadRequest, "not in any area yet; pass an area")
+		return
+	}
+
+	// The PokeAPI is asked outside the lock so that a slow response doesn't hold up
+	// other requests. Fetching the growth rate (and with it the species) here puts it
+	// in the cache for throwPokeball.
+	areaDetails, err := fetchLocationArea(s.cachePtr, area)
+	if err != nil {
+		writeError(w, errorStatus(err), "can't get area %v: %v", area, err)
+		return
+	}
+	if !canEncounter(areaDetails, request.Pokemon, version) {
+		writeError(w, http.StatusUnprocessableEntity, "there is no wild %v in %v", request.Pokemon, area)
+		return
+	}
+	pokemon, err := fetchPokemon(s.cachePtr, request.Pokemon)
+	if err != nil {
+		writeError(w, errorStatus(err), "can't get pokemon %v: %v", request.Pokemon, err)
+		return
+	}
+	if _, err := fetchGrowthRate(s.cachePtr, pokemon.Species.Name); err != nil {
+		writeError(w, errorStatus(err), "can't get growth rate of %v: %v", pokemon.Species.Name, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if area != s.configPtr.CurrentArea {
+		s.configPtr.CurrentArea = area
+		s.configPtr.Encounter = nil
+		s.configPtr.unsaved = true
+	}
+	owned, where, err := throwPokeball(s.configPtr, s.cachePtr, pokemon, areaDetails, s.pokedex)
+	if err != nil {
+		writeError(w, errorStatus(err), "catching %v failed: %v", request.Pokemon, err)
+		return
+	}
+	checkAchievements(s.configPtr, s.pokedex, time.Now())
+	if err := s.save(); err != nil {
+		writeError(w, http.StatusInternalServerError, "saving the game failed: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, struct {
+		Caught  bool          `json:"caught"`
+		SentTo  string        `json:"sent_to,omitempty"`
+		Pokemon *ownedPokemon `json:"pokemon,omitempty"`
+	}{Caught: owned != nil, SentTo: where, Pokemon: owned})
+}
+
+// handleLocations serves GET /locations?page=N[&limit=L]: a page of location areas,
+// limited to the selected game like map.
+func (s *apiServer) handleLocations(w http.ResponseWriter, r *http.Request) {
+	page, limit := 1, defaultMapLimit
+	if value := r.URL.Query().Get("page"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			writeError(w, http.StatusBadRequest, "page must be a number from 1 up")
+			return
+		}
+		page = parsed
+	}
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxMapLimit {
+			writeError(w, http.StatusBadRequest, "limit must be a number from 1 to %d", maxMapLimit)
+			return
+		}
+		limit = parsed
+	}
+
+	// Charting a game's areas takes a request per location, so the page is built
+	// from a copy of the game settings outside the lock, and the areas kept after.
+	s.mu.Lock()
+	game := config{Version: s.configPtr.Version, VersionGroup: s.configPtr.VersionGroup, versionAreas: s.configPtr.versionAreas}
+	s.mu.Unlock()
+	names, total, err := areaPage(&game, s.cachePtr, (page-1)*limit, limit)
+	if err == nil && game.Version != "" {
+		s.mu.Lock()
+		if s.configPtr.Version == game.Version && s.configPtr.versionAreas == nil {
+			s.configPtr.versionAreas = game.versionAreas
+		}
+		s.mu.Unlock()
+	}
+	if err != nil {
+		writeError(w, errorStatus(err), "can't get locations: %v", err)
+		return
+	}
+	if len(names) == 0 && page > 1 {
+		writeError(w, http.StatusNotFound, "page %d doesn't exist; there are %d", page, pageCount(total, limit))
+		return
+	}
+	writeJSON(w, http.StatusOK, struct {
+		Page    int      `json:"page"`
+		Pages   int      `json:"pages"`
+		Count   int      `json:"count"`
+		Results []string `json:"results"`
+	}{Page: page, Pages: pageCount(total, limit), Count: total, Results: names})
+}
+
+// serve runs the HTTP API until interrupted, then waits for requests in flight to finish.
+// args are the command line arguments after "serve", e.g. --addr :8080.
+func serve(args []string, savePath string, configPtr *config, pokedex map[string]pokemonDetails) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := flags.String("addr", ":8080", "address to listen on")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	server := &apiServer{
+		configPtr: configPtr,
+		cachePtr:  internal.NewCache(30 * time.Second),
+		pokedex:   pokedex,
+		savePath:  savePath,
+	}
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           server.routes(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- httpServer.ListenAndServe()
+	}()
+	color.New(color.FgCyan, color.Bold).Printf("Serving the Pokedex API on %v (Ctrl+C to stop)\n", *addr)
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	color.New(color.FgHiBlack).Println("Shutting down...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return httpServer.Shutdown(shutdownCtx)
+}
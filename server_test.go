@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
+)
+
+// testAPIServer returns a server with one caught species and nothing saved to disk.
+func testAPIServer() *apiServer {
+	configPtr := &config{Seen: map[string]bool{"pidgey": true, "pikachu": true}}
+	configPtr.Party = []*ownedPokemon{{ID: 1, Species: "pikachu", Level: 5}}
+	return &apiServer{
+		configPtr: configPtr,
+		cachePtr:  internal.NewCache(30 * time.Second),
+		pokedex:   map[string]pokemonDetails{"pikachu": {ID: 25, Name: "pikachu"}},
+	}
+}
+
+// TestServerPokedex checks the caught, seen and owned lists of GET /pokedex.
+func TestServerPokedex(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	testAPIServer().routes().ServeHTTP(recorder, httptest.NewRequest("GET", "/pokedex", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, Got %d", recorder.Code)
+	}
+
+	var body struct {
+		Caught []dexRow        `json:"caught"`
+		Seen   []string        `json:"seen"`
+		Owned  []*ownedPokemon `json:"owned"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("could not parse response: %v", err)
+	}
+	if len(body.Caught) != 1 || body.Caught[0].Name != "pikachu" || body.Caught[0].Owned != 1 {
+		t.Errorf("Expected one caught pikachu, owned once, Got %+v", body.Caught)
+	}
+	if len(body.Seen) != 2 || body.Seen[0] != "pidgey" || len(body.Owned) != 1 {
+		t.Errorf("Expected 2 seen and 1 owned, Got %v and %v", body.Seen, body.Owned)
+	}
+}
+
+// TestServerErrors checks that bad requests get JSON error bodies without touching the PokeAPI.
+func TestServerErrors(t *testing.T) {
+	cases := []struct {
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{method: "GET", path: "/nothing", status: http.StatusNotFound},
+		{method: "GET", path: "/catch", status: http.StatusMethodNotAllowed},
+		{method: "DELETE", path: "/pokemon/pikachu", status: http.StatusMethodNotAllowed},
+		{method: "POST", path: "/catch", body: "{not json", status: http.StatusBadRequest},
+		{method: "POST", path: "/catch", body: `{"area": "route-1"}`, status: http.StatusBadRequest},
+		{method: "POST", path: "/catch", body: `{"pokemon": "pidgey"}`, status: http.StatusBadRequest},
+		{method: "GET", path: "/locations?page=0", status: http.StatusBadRequest},
+		{method: "GET", path: "/locations?limit=abc", status: http.StatusBadRequest},
+	}
+	for _, c := range cases {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(c.method, c.path, strings.NewReader(c.body))
+		testAPIServer().routes().ServeHTTP(recorder, request)
+
+		if recorder.Code != c.status {
+			t.Errorf("%v %v: Expected status %d, Got %d", c.method, c.path, c.status, recorder.Code)
+		}
+		var body apiError
+		if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil || body.Error == "" {
+			t.Errorf("%v %v: Expected a JSON error body, Got %q", c.method, c.path, recorder.Body.String())
+		}
+	}
+}
+
+// TestErrorStatus checks how PokeAPI errors map to response codes.
+func TestErrorStatus(t *testing.T) {
+	if status := errorStatus(&statusError{URL: "x", StatusCode: 404}); status != http.StatusNotFound {
+		t.Errorf("Expected 404, Got %d", status)
+	}
+	if status := errorStatus(&statusError{URL: "x", StatusCode: 500}); status != http.StatusBadGateway {
+		t.Errorf("Expected 502, Got %d", status)
+	}
+	if status := errorStatus(errors.New("connection refused")); status != http.StatusBadGateway {
+		t.Errorf("Expected 502, Got %d", status)
+	}
+	if status := errorStatus(fmt.Errorf("fetching: %w", &statusError{URL: "x", StatusCode: 404})); status != http.StatusNotFound {
+		t.Errorf("Expected a wrapped 404 to stay 404, Got %d", status)
+	}
+}
+
+// TestServerLocationsGame checks that GET /locations pages through the selected game's areas.
+func TestServerLocationsGame(t *testing.T) {
+	server := testAPIServer()
+	server.configPtr.Version, server.configPtr.VersionGroup = "red", "red-blue"
+	server.configPtr.versionAreas = []string{"pallet-town-area", "route-1-area", "viridian-forest-area"}
+
+	recorder := httptest.NewRecorder()
+	server.routes().ServeHTTP(recorder, httptest.NewRequest("GET", "/locations?page=2&limit=2", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, Got %d: %v", recorder.Code, recorder.Body.String())
+	}
+	var body struct {
+		Pages   int      `json:"pages"`
+		Results []string `json:"results"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("could not parse response: %v", err)
+	}
+	if body.Pages != 2 || len(body.Results) != 1 || body.Results[0] != "viridian-forest-area" {
+		t.Errorf("Expected page 2 of 2 with viridian-forest-area, Got %+v", body)
+	}
+}
@@ -0,0 +1,394 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
+	"github.com/fatih/color"
+)
+
+// showdownStats maps Pokémon Showdown's stat abbreviations to PokeAPI stat names.
+var showdownStats = map[string]string{
+	"HP":  "hp",
+	"Atk": "attack",
+	"Def": "defense",
+	"SpA": "special-attack",
+	"SpD": "special-defense",
+	"Spe": "speed",
+}
+
+// showdownSet is one Pokémon of a Showdown paste. Names are kept in PokeAPI form
+// (e.g. "mr-mime", "volt-tackle"); 0 or nil means the paste left a value out.
+type showdownSet struct {
+	Nickname string
+	Species  string
+	Gender   string // "male", "female" or ""
+	Item     string
+	Ability  string
+	Level    int
+	LevelSet bool // Whether the paste has a Level line, so that "Level: 0" isn't taken as left out
+	Shiny    bool
+	Nature   string
+	EVs      map[string]int
+	IVs      map[string]int
+	Moves    []string
+}
+
+// showdownName turns a PokeAPI name into the way Showdown writes it, e.g.
+// "volt-tackle" into "Volt Tackle". Species keep their hyphens ("Ninetales-Alola").
+func showdownName(name string, keepHyphens bool) string {
+	parts := strings.Split(name, "-")
+	for i, part := range parts {
+		if part != "" {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	if keepHyphens {
+		return strings.Join(parts, "-")
+	}
+	return strings.Join(parts, " ")
+}
+
+// apiName turns a name from a Showdown paste into PokeAPI form, e.g. "King's Shield"
+// into "kings-shield". The type of Hidden Power is dropped: "Hidden Power [Fire]".
+func apiName(name string) string {
+	if before, _, found := strings.Cut(name, "["); found {
+		name = before
+	}
+	return internal.NormalizeName(name)
+}
+
+// parseShowdownStats parses a stat spread such as "252 Atk / 4 SpD / 252 Spe".
+func parseShowdownStats(text string) (map[string]int, error) {
+	stats := make(map[string]int)
+	for _, part := range strings.Split(text, "/") {
+		fields := strings.Fields(part)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("can't read stat %q", strings.TrimSpace(part))
+		}
+		value, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("can't read stat %q", strings.TrimSpace(part))
+		}
+		stat, ok := showdownStats[fields[1]]
+		if !ok {
+			return nil, fmt.Errorf("unknown stat %q", fields[1])
+		}
+		stats[stat] = value
+	}
+	return stats, nil
+}
+
+// parseShowdownHeader parses the first line of a set: "Nickname (Species) (M) @ Item".
+func parseShowdownHeader(line string, set *showdownSet) {
+	if before, item, found := strings.Cut(line, " @ "); found {
+		set.Item = apiName(item)
+		line = before
+	}
+	line = strings.TrimSpace(line)
+	if strings.HasSuffix(line, " (M)") {
+		set.Gender = "male"
+		line = strings.TrimSuffix(line, " (M)")
+	} else if strings.HasSuffix(line, " (F)") {
+		set.Gender = "female"
+		line = strings.TrimSuffix(line, " (F)")
+	}
+
+	// A nickname puts the species in parentheses after it.
+	if open := strings.LastIndex(line, " ("); open >= 0 && strings.HasSuffix(line, ")") {
+		set.Nickname = strings.TrimSpace(line[:open])
+		set.Species = apiName(line[open+2 : len(line)-1])
+		return
+	}
+	set.Species = apiName(line)
+}
+
+// parseShowdown reads the sets of a Showdown paste. Sets are separated by blank lines;
+// lines the CLI has no use for, such as "Tera Type:", are skipped.
+func parseShowdown(text string) ([]showdownSet, error) {
+	sets := []showdownSet{}
+	var current *showdownSet
+	for number, rawLine := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line := strings.TrimSpace(rawLine)
+		if line == "" {
+			current = nil
+			continue
+		}
+		if current == nil {
+			sets = append(sets, showdownSet{})
+			current = &sets[len(sets)-1]
+			parseShowdownHeader(line, current)
+			continue
+		}
+
+		key, value, hasValue := strings.Cut(line, ":")
+		value = strings.TrimSpace(value)
+		switch {
+		case strings.HasPrefix(line, "-"):
+			current.Moves = append(current.Moves, apiName(strings.TrimPrefix(line, "-")))
+		case strings.HasSuffix(line, " Nature"):
+			current.Nature = strings.ToLower(strings.TrimSuffix(line, " Nature"))
+		case hasValue && key == "Ability":
+			current.Ability = apiName(value)
+		case hasValue && key == "Level":
+			level, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: can't read level %q", number+1, value)
+			}
+			current.Level, current.LevelSet = level, true
+		case hasValue && key == "Shiny":
+			current.Shiny = strings.EqualFold(value, "yes")
+		case hasValue && (key == "EVs" || key == "IVs"):
+			stats, err := parseShowdownStats(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", number+1, err)
+			}
+			if key == "EVs" {
+				current.EVs = stats
+			} else {
+				current.IVs = stats
+			}
+		}
+	}
+	return sets, nil
+}
+
+// formatShowdownStats writes a stat spread, leaving out stats equal to skip
+// (0 for EVs, 31 for IVs) the way Showdown does.
+func formatShowdownStats(stats map[string]int, skip int) string {
+	parts := []string{}
+	for _, stat := range internal.StatNames {
+		value, ok := stats[stat]
+		if !ok || value == skip {
+			continue
+		}
+		for abbreviation, name := range showdownStats {
+			if name == stat {
+				parts = append(parts, fmt.Sprintf("%d %v", value, abbreviation))
+			}
+		}
+	}
+	return strings.Join(parts, " / ")
+}
+
+// formatShowdown writes sets as a Showdown paste.
+func formatShowdown(sets []showdownSet) string {
+	var b strings.Builder
+	for i, set := range sets {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		species := showdownName(set.Species, true)
+		if set.Nickname != "" && set.Nickname != set.Species {
+			b.WriteString(fmt.Sprintf("%v (%v)", set.Nickname, species))
+		} else {
+			b.WriteString(species)
+		}
+		switch set.Gender {
+		case "male":
+			b.WriteString(" (M)")
+		case "female":
+			b.WriteString(" (F)")
+		}
+		if set.Item != "" {
+			b.WriteString(" @ " + showdownName(set.Item, false))
+		}
+		b.WriteString("\n")
+
+		if set.Ability != "" {
+			b.WriteString(fmt.Sprintf("Ability: %v\n", showdownName(set.Ability, false)))
+		}
+		if set.Level != 0 && set.Level != internal.MaxLevel {
+			b.WriteString(fmt.Sprintf("Level: %d\n", set.Level))
+		}
+		if set.Shiny {
+			b.WriteString("Shiny: Yes\n")
+		}
+		if evs := formatShowdownStats(set.EVs, 0); evs != "" {
+			b.WriteString(fmt.Sprintf("EVs: %v\n", evs))
+		}
+		if set.Nature != "" {
+			b.WriteString(fmt.Sprintf("%v Nature\n", showdownName(set.Nature, false)))
+		}
+		if ivs := formatShowdownStats(set.IVs, internal.MaxIV); ivs != "" {
+			b.WriteString(fmt.Sprintf("IVs: %v\n", ivs))
+		}
+		for _, move := range set.Moves {
+			b.WriteString(fmt.Sprintf("- %v\n", showdownName(move, false)))
+		}
+	}
+	return b.String()
+}
+
+// teamSets turns the team into Showdown sets. Owned members bring their own
+// nickname, level, nature, IVs, EVs and moves.
+func teamSets(entries []teamEntry) []showdownSet {
+	sets := []showdownSet{}
+	for _, entry := range entries {
+		member := entry.member
+		set := showdownSet{
+			Nickname: member.Nickname,
+			Species:  member.Pokemon,
+			Gender:   member.Gender,
+			Item:     member.Item,
+			Ability:  member.Ability,
+			Level:    member.Level,
+			Shiny:    member.Shiny,
+			Nature:   member.Nature,
+			EVs:      member.EVs,
+			IVs:      member.IVs,
+			Moves:    entry.moves,
+		}
+		if owned := entry.owned; owned != nil {
+			set.Nickname, set.Level, set.Shiny = owned.Nickname, owned.Level, owned.Shiny
+			set.Nature, set.EVs, set.IVs = owned.Nature, owned.EVs, owned.IVs
+			if owned.Gender != "genderless" {
+				set.Gender = owned.Gender
+			}
+		}
+		sets = append(sets, set)
+	}
+	return sets
+}
+
+// setProblems lists what is illegal about a set: abilities and moves the Pokémon
+// can't have, unknown natures and out-of-range levels, EVs and IVs.
+func setProblems(set showdownSet, details pokemonDetails) []string {
+	problems := []string{}
+	if set.Ability != "" {
+		legal := false
+		for _, a := range details.Abilities {
+			if a.Ability.Name == set.Ability {
+				legal = true
+			}
+		}
+		if !legal {
+			problems = append(problems, fmt.Sprintf("%v can't have the ability %v", details.Name, set.Ability))
+		}
+	}
+	if len(set.Moves) > 4 {
+		problems = append(problems, fmt.Sprintf("%d moves, but a Pokémon knows at most four", len(set.Moves)))
+	}
+	for _, move := range set.Moves {
+		if !canLearn(details, move) {
+			problems = append(problems, fmt.Sprintf("%v can't learn %v", details.Name, move))
+		}
+	}
+	if set.Nature != "" && !internal.IsNature(set.Nature) {
+		problems = append(problems, fmt.Sprintf("unknown nature %v", set.Nature))
+	}
+	if (set.LevelSet || set.Level != 0) && (set.Level < 1 || set.Level > internal.MaxLevel) {
+		problems = append(problems, fmt.Sprintf("level %d is not between 1 and %d", set.Level, internal.MaxLevel))
+	}
+	total := 0
+	for stat, value := range set.EVs {
+		total += value
+		if value < 0 || value > internal.MaxEV {
+			problems = append(problems, fmt.Sprintf("%d %v EVs is not between 0 and %d", value, stat, internal.MaxEV))
+		}
+	}
+	if total > internal.MaxTotalEVs {
+		problems = append(problems, fmt.Sprintf("%d EVs in total, more than %d", total, internal.MaxTotalEVs))
+	}
+	for stat, value := range set.IVs {
+		if value < 0 || value > internal.MaxIV {
+			problems = append(problems, fmt.Sprintf("%d %v IVs is not between 0 and %d", value, stat, internal.MaxIV))
+		}
+	}
+	return problems
+}
+
+// teamExport prints the team as a Showdown paste, or writes it to a file when given a path.
+func teamExport(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	if len(configPtr.Team) == 0 {
+		color.New(color.FgHiMagenta, color.Bold).Println("Your team is empty; there is nothing to export.")
+		return nil
+	}
+	entries, err := loadTeam(configPtr, cachePtr, pokedex)
+	if err != nil {
+		return err
+	}
+	paste := formatShowdown(teamSets(entries))
+
+	if len(args) == 0 {
+		fmt.Print(paste)
+		return nil
+	}
+	path := originalWord(configPtr.typed, args[0])
+	if err := os.WriteFile(path, []byte(paste), 0o644); err != nil {
+		return err
+	}
+	color.New(color.FgHiGreen, color.Bold).Printf("Team exported to %v.\n", path)
+	return nil
+}
+
+// teamImport replaces the team with the sets of a Showdown paste. Every set is checked
+// against the PokeAPI first; if anything is illegal nothing is imported.
+func teamImport(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	if len(args) == 0 {
+		color.New(color.FgHiRed, color.Bold).Println("Usage: team import <file>")
+		return nil
+	}
+	path := originalWord(configPtr.typed, args[0])
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	sets, err := parseShowdown(string(data))
+	if err != nil {
+		color.New(color.FgHiRed, color.Bold).Printf("Can't read %v: %v\n", path, err)
+		return nil
+	}
+	if len(sets) == 0 || len(sets) > maxTeamSize {
+		color.New(color.FgHiRed, color.Bold).Printf("A team has 1 to %d Pokémon; %v has %d.\n", maxTeamSize, path, len(sets))
+		return nil
+	}
+
+	members := []*teamMember{}
+	illegal := 0
+	for i, set := range sets {
+		details, ok := pokedex[set.Species]
+		if !ok {
+			var fetchErr error
+			details, fetchErr = fetchPokemon(cachePtr, set.Species)
+			if isNotFound(fetchErr) {
+				illegal++
+				color.New(color.FgHiRed, color.Bold).Printf("Set %d: there is no Pokémon called %v.\n", i+1, set.Species)
+				printDidYouMean(similarNames(set.Species, knownNames(configPtr, cachePtr, "pokemon")))
+				continue
+			}
+			if fetchErr != nil {
+				return fetchErr
+			}
+		}
+		for _, problem := range setProblems(set, details) {
+			illegal++
+			color.New(color.FgHiRed, color.Bold).Printf("Set %d (%v): %v.\n", i+1, set.Species, problem)
+		}
+		members = append(members, &teamMember{
+			Pokemon:  set.Species,
+			Moves:    set.Moves,
+			Nickname: set.Nickname,
+			Gender:   set.Gender,
+			Item:     set.Item,
+			Ability:  set.Ability,
+			Level:    set.Level,
+			Shiny:    set.Shiny,
+			Nature:   set.Nature,
+			EVs:      set.EVs,
+			IVs:      set.IVs,
+		})
+	}
+	if illegal > 0 {
+		color.New(color.FgHiYellow, color.Bold).Printf("The team was not imported: %d illegal entries found.\n", illegal)
+		return nil
+	}
+
+	configPtr.Team = members
+	configPtr.unsaved = true
+	color.New(color.FgHiGreen, color.Bold).Printf("Imported %d Pokémon from %v.\n", len(members), path)
+	return nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// testPaste is a Showdown paste with two sets.
+const testPaste = `Sparky (Pikachu) (F) @ Light Ball
+Ability: Lightning Rod
+Level: 50
+Shiny: Yes
+Tera Type: Electric
+EVs: 4 HP / 252 SpA / 252 Spe
+Timid Nature
+IVs: 0 Atk
+- Thunderbolt
+- Volt Tackle
+- Hidden Power [Ice]
+
+Mr. Mime
+Ability: Filter
+- Psychic
+`
+
+// TestParseShowdown checks that every part of a set is read into PokeAPI names.
+func TestParseShowdown(t *testing.T) {
+	sets, err := parseShowdown(testPaste)
+	if err != nil {
+		t.Fatalf("could not parse test paste: %v", err)
+	}
+	if len(sets) != 2 {
+		t.Fatalf("Expected 2 sets, Got %d", len(sets))
+	}
+
+	set := sets[0]
+	if set.Nickname != "Sparky" || set.Species != "pikachu" || set.Gender != "female" || set.Item != "light-ball" {
+		t.Errorf("Expected Sparky, pikachu, female, light-ball, Got %v, %v, %v, %v", set.Nickname, set.Species, set.Gender, set.Item)
+	}
+	if set.Ability != "lightning-rod" || set.Level != 50 || !set.Shiny || set.Nature != "timid" {
+		t.Errorf("Expected lightning-rod, level 50, shiny, timid, Got %v, %v, %v, %v", set.Ability, set.Level, set.Shiny, set.Nature)
+	}
+	if set.EVs["special-attack"] != 252 || set.EVs["hp"] != 4 || set.IVs["attack"] != 0 || len(set.IVs) != 1 {
+		t.Errorf("Expected the EV and IV spreads, Got %v and %v", set.EVs, set.IVs)
+	}
+	expectedMoves := []string{"thunderbolt", "volt-tackle", "hidden-power"}
+	if len(set.Moves) != len(expectedMoves) {
+		t.Fatalf("Expected moves %v, Got %v", expectedMoves, set.Moves)
+	}
+	for i := range expectedMoves {
+		if set.Moves[i] != expectedMoves[i] {
+			t.Errorf("Expected moves %v, Got %v", expectedMoves, set.Moves)
+		}
+	}
+
+	if sets[1].Species != "mr-mime" || sets[1].Nickname != "" {
+		t.Errorf("Expected mr-mime without a nickname, Got %q (%q)", sets[1].Species, sets[1].Nickname)
+	}
+
+	if _, err := parseShowdown("Pikachu\nEVs: 252 Attack"); err == nil {
+		t.Errorf("Expected an error for an unknown stat name")
+	}
+}
+
+// TestShowdownRoundTrip checks that a formatted paste parses back into the same sets.
+func TestShowdownRoundTrip(t *testing.T) {
+	sets, err := parseShowdown(testPaste)
+	if err != nil {
+		t.Fatalf("could not parse test paste: %v", err)
+	}
+	again, err := parseShowdown(formatShowdown(sets))
+	if err != nil {
+		t.Fatalf("could not parse formatted paste: %v", err)
+	}
+
+	a, _ := json.Marshal(sets)
+	b, _ := json.Marshal(again)
+	if string(a) != string(b) {
+		t.Errorf("Expected %s, Got %s", a, b)
+	}
+}
+
+// TestSetProblems checks that illegal abilities, moves, natures and spreads are reported.
+func TestSetProblems(t *testing.T) {
+	var details pokemonDetails
+	data := `{"name": "pikachu",
+		"abilities": [{"ability": {"name": "static"}}, {"ability": {"name": "lightning-rod"}, "is_hidden": true}],
+		"moves": [{"move": {"name": "thunderbolt"}}, {"move": {"name": "volt-tackle"}}]}`
+	if err := json.Unmarshal([]byte(data), &details); err != nil {
+		t.Fatalf("could not parse test pokemon: %v", err)
+	}
+
+	legal := showdownSet{Species: "pikachu", Ability: "lightning-rod", Nature: "timid", Moves: []string{"thunderbolt"},
+		EVs: map[string]int{"speed": 252, "special-attack": 252, "hp": 4}}
+	if problems := setProblems(legal, details); len(problems) != 0 {
+		t.Errorf("Expected no problems, Got %v", problems)
+	}
+
+	illegal := showdownSet{Species: "pikachu", Ability: "levitate", Nature: "grumpy", Level: 101, Moves: []string{"surf"},
+		EVs: map[string]int{"speed": 255, "attack": 252, "hp": 252}, IVs: map[string]int{"hp": 32}}
+	// ability, move, nature, level, speed EVs, EV total and hp IVs
+	if problems := setProblems(illegal, details); len(problems) != 7 {
+		t.Errorf("Expected 7 problems, Got %d: %v", len(problems), problems)
+	}
+
+	// A level written as 0 is not the same as no level at all.
+	sets, err := parseShowdown("Pikachu\nLevel: 0\n")
+	if err != nil {
+		t.Fatalf("could not parse level 0: %v", err)
+	}
+	if problems := setProblems(sets[0], details); len(problems) != 1 {
+		t.Errorf("Expected level 0 to be a problem, Got %v", problems)
+	}
+}
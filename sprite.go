@@ -0,0 +1,198 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif" // A few version sprites are GIFs
+	_ "image/png"
+	"os"
+
+	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
+	"github.com/fatih/color"
+)
+
+// spriteWidth is how many characters wide the sprite command draws sprites;
+// inspect uses the smaller inspectSpriteWidth.
+const (
+	spriteWidth        = 48
+	inspectSpriteWidth = 32
+)
+
+// spriteSet is the front/back, default/shiny sprite URLs of one game.
+type spriteSet struct {
+	FrontDefault string
+	FrontShiny   string
+	BackDefault  string
+	BackShiny    string
+}
+
+// gameSprites returns the sprite URLs for a game (version group), or false if the
+// PokeAPI has no sprites for it. An empty game means the default, modern sprites.
+func gameSprites(details pokemonDetails, game string) (spriteSet, bool) {
+	sprites := details.Sprites
+	versions := sprites.Versions
+	switch game {
+	case "":
+		return spriteSet{sprites.FrontDefault, sprites.FrontShiny, sprites.BackDefault, sprites.BackShiny}, true
+	case "red-blue":
+		s := versions.GenerationI.RedBlue
+		return spriteSet{FrontDefault: s.FrontDefault, BackDefault: s.BackDefault}, true
+	case "yellow":
+		s := versions.GenerationI.Yellow
+		return spriteSet{FrontDefault: s.FrontDefault, BackDefault: s.BackDefault}, true
+	case "gold":
+		s := versions.GenerationIi.Gold
+		return spriteSet{s.FrontDefault, s.FrontShiny, s.BackDefault, s.BackShiny}, true
+	case "silver":
+		s := versions.GenerationIi.Silver
+		return spriteSet{s.FrontDefault, s.FrontShiny, s.BackDefault, s.BackShiny}, true
+	case "gold-silver":
+		s := versions.GenerationIi.Gold
+		return spriteSet{s.FrontDefault, s.FrontShiny, s.BackDefault, s.BackShiny}, true
+	case "crystal":
+		s := versions.GenerationIi.Crystal
+		return spriteSet{s.FrontDefault, s.FrontShiny, s.BackDefault, s.BackShiny}, true
+	case "ruby-sapphire":
+		s := versions.GenerationIii.RubySapphire
+		return spriteSet{s.FrontDefault, s.FrontShiny, s.BackDefault, s.BackShiny}, true
+	case "emerald":
+		s := versions.GenerationIii.Emerald
+		return spriteSet{FrontDefault: s.FrontDefault, FrontShiny: s.FrontShiny}, true
+	case "firered-leafgreen":
+		s := versions.GenerationIii.FireredLeafgreen
+		return spriteSet{s.FrontDefault, s.FrontShiny, s.BackDefault, s.BackShiny}, true
+	case "diamond-pearl":
+		s := versions.GenerationIv.DiamondPearl
+		return spriteSet{s.FrontDefault, s.FrontShiny, s.BackDefault, s.BackShiny}, true
+	case "platinum":
+		s := versions.GenerationIv.Platinum
+		return spriteSet{s.FrontDefault, s.FrontShiny, s.BackDefault, s.BackShiny}, true
+	case "heartgold-soulsilver":
+		s := versions.GenerationIv.HeartgoldSoulsilver
+		return spriteSet{s.FrontDefault, s.FrontShiny, s.BackDefault, s.BackShiny}, true
+	case "black-white", "black-2-white-2":
+		s := versions.GenerationV.BlackWhite
+		return spriteSet{s.FrontDefault, s.FrontShiny, s.BackDefault, s.BackShiny}, true
+	case "x-y":
+		s := versions.GenerationVi.XY
+		return spriteSet{FrontDefault: s.FrontDefault, FrontShiny: s.FrontShiny}, true
+	case "omega-ruby-alpha-sapphire", "omegaruby-alphasapphire":
+		s := versions.GenerationVi.OmegarubyAlphasapphire
+		return spriteSet{FrontDefault: s.FrontDefault, FrontShiny: s.FrontShiny}, true
+	case "sun-moon", "ultra-sun-ultra-moon":
+		s := versions.GenerationVii.UltraSunUltraMoon
+		return spriteSet{FrontDefault: s.FrontDefault, FrontShiny: s.FrontShiny}, true
+	}
+	return spriteSet{}, false
+}
+
+// spriteURL picks the sprite URL for a game, side and shininess.
+func spriteURL(details pokemonDetails, game string, shiny, back bool) (string, error) {
+	set, ok := gameSprites(details, game)
+	if !ok {
+		return "", fmt.Errorf("there are no sprites for the game %v", game)
+	}
+
+	url := set.FrontDefault
+	switch {
+	case back && shiny:
+		url = set.BackShiny
+	case back:
+		url = set.BackDefault
+	case shiny:
+		url = set.FrontShiny
+	}
+	if url == "" {
+		return "", fmt.Errorf("%v has no such sprite in %v", details.Name, gameOrDefault(game))
+	}
+	return url, nil
+}
+
+// gameOrDefault names a game for messages, where "" means the default sprites.
+func gameOrDefault(game string) string {
+	if game == "" {
+		return "the default sprites"
+	}
+	return game
+}
+
+// renderSprite downloads a sprite (through the cache) and draws it for the current terminal.
+func renderSprite(cachePtr *internal.Cache, url string, width int) (string, error) {
+	val, err := fetchData(cachePtr, url)
+	if err != nil {
+		return "", err
+	}
+	img, _, err := image.Decode(bytes.NewReader(val))
+	if err != nil {
+		return "", err
+	}
+	mode := internal.DetectColorMode(os.Getenv, color.NoColor)
+	return internal.RenderImage(img, mode, width), nil
+}
+
+// sprite draws a Pokémon's sprite in the terminal:
+// sprite <pokemon> [--shiny] [--back] [--game red-blue]. An owned Pokémon's
+// #ID or nickname draws it the way it looks, shiny or not.
+func sprite(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	positional, flags := parseFlags(args, "shiny", "back")
+	if len(positional) == 0 {
+		color.New(color.FgHiRed, color.Bold).Println("Error: missing pokemon argument.")
+		return nil
+	}
+
+	name, ownedShiny := positional[0], false
+	if owned, found := findOwned(configPtr, name); found && owned.Species != name {
+		name, ownedShiny = owned.Species, owned.Shiny
+	}
+	details, ok := pokedex[name]
+	if !ok {
+		var err error
+		details, err = fetchPokemon(cachePtr, name)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, shiny := flags["shiny"]
+	shiny = shiny || ownedShiny
+	_, back := flags["back"]
+	game := flags["game"]
+	if game == "" {
+		// The selected game's sprites, if the PokeAPI has that one, else the default ones.
+		game = configPtr.VersionGroup
+		if _, err := spriteURL(details, game, shiny, back); err != nil {
+			game = ""
+		}
+	}
+	url, err := spriteURL(details, game, shiny, back)
+	if err != nil {
+		return err
+	}
+	drawing, err := renderSprite(cachePtr, url, spriteWidth)
+	if err != nil {
+		return err
+	}
+	fmt.Print(drawing)
+	return nil
+}
+
+// printInspectSprite draws the sprite at the top of inspect, unless --no-sprite was given.
+// Sprites are a nice extra, so a failed download is skipped quietly.
+func printInspectSprite(configPtr *config, cachePtr *internal.Cache, details pokemonDetails, shiny bool, flags map[string]string) {
+	if _, skip := flags["no-sprite"]; skip {
+		return
+	}
+	url, err := spriteURL(details, configPtr.VersionGroup, shiny, false)
+	if err != nil {
+		url, err = spriteURL(details, "", shiny, false)
+	}
+	if err != nil {
+		return
+	}
+	drawing, err := renderSprite(cachePtr, url, inspectSpriteWidth)
+	if err != nil {
+		return
+	}
+	fmt.Print(drawing)
+}
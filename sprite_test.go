@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+)
+
+// TestSpriteURL checks sprite selection by game, side and shininess.
+func TestSpriteURL(t *testing.T) {
+	var details pokemonDetails
+	details.Name = "pikachu"
+	details.Sprites.FrontDefault = "front.png"
+	details.Sprites.FrontShiny = "front-shiny.png"
+	details.Sprites.BackShiny = "back-shiny.png"
+	details.Sprites.Versions.GenerationI.RedBlue.FrontDefault = "red-blue.png"
+
+	cases := []struct {
+		game     string
+		shiny    bool
+		back     bool
+		expected string // "" means an error is expected
+	}{
+		{expected: "front.png"},
+		{shiny: true, expected: "front-shiny.png"},
+		{shiny: true, back: true, expected: "back-shiny.png"},
+		{back: true, expected: ""}, // No back sprite in the test data
+		{game: "red-blue", expected: "red-blue.png"},
+		{game: "red-blue", shiny: true, expected: ""}, // There were no shinies in generation I
+		{game: "pokemon-purple", expected: ""},
+	}
+
+	for i, c := range cases {
+		url, err := spriteURL(details, c.game, c.shiny, c.back)
+		if c.expected == "" {
+			if err == nil {
+				t.Errorf("Test case %v: expected an error, Got %v", i, url)
+			}
+			continue
+		}
+		if err != nil || url != c.expected {
+			t.Errorf("Test case %v: Expected %v, Got %v (%v)", i, c.expected, url, err)
+		}
+	}
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
+	"github.com/fatih/color"
+)
+
+const (
+	maxPartySize = 6  // A trainer can carry at most six Pokémon
+	numBoxes     = 8  // Number of PC boxes
+	boxCapacity  = 30 // Pokémon per PC box
+)
+
+// allOwned returns every owned Pokémon, party first, then the boxes in order.
+func (configPtr *config) allOwned() []*ownedPokemon {
+	all := append([]*ownedPokemon{}, configPtr.Party...)
+	for _, box := range configPtr.Boxes {
+		all = append(all, box...)
+	}
+	return all
+}
+
+// locateOwned finds where an owned Pokémon is stored.
+// box is -1 when it is in the party; index is its position in the party or box.
+func (configPtr *config) locateOwned(owned *ownedPokemon) (box int, index int, ok bool) {
+	for i, member := range configPtr.Party {
+		if member == owned {
+			return -1, i, true
+		}
+	}
+	for b, pcBox := range configPtr.Boxes {
+		for i, member := range pcBox {
+			if member == owned {
+				return b, i, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// removeOwned takes an owned Pokémon out of the party or its box.
+func (configPtr *config) removeOwned(owned *ownedPokemon) {
+	box, index, ok := configPtr.locateOwned(owned)
+	if !ok {
+		return
+	}
+	if box == -1 {
+		configPtr.Party = append(configPtr.Party[:index], configPtr.Party[index+1:]...)
+		return
+	}
+	configPtr.Boxes[box] = append(configPtr.Boxes[box][:index], configPtr.Boxes[box][index+1:]...)
+}
+
+// boxWithRoom returns the first PC box that is not full, or -1 if every box is full.
+func (configPtr *config) boxWithRoom() int {
+	for len(configPtr.Boxes) < numBoxes {
+		configPtr.Boxes = append(configPtr.Boxes, []*ownedPokemon{})
+	}
+	for b, box := range configPtr.Boxes {
+		if len(box) < boxCapacity {
+			return b
+		}
+	}
+	return -1
+}
+
+// storeCaught puts a newly caught Pokémon in the party, or in the PC when the party is full.
+// It returns a description of where the Pokémon went, or an error if there is no room at all.
+func (configPtr *config) storeCaught(owned *ownedPokemon) (string, error) {
+	if len(configPtr.Party) < maxPartySize {
+		configPtr.Party = append(configPtr.Party, owned)
+		return "your party", nil
+	}
+	box := configPtr.boxWithRoom()
+	if box == -1 {
+		return "", fmt.Errorf("every PC box is full")
+	}
+	configPtr.Boxes[box] = append(configPtr.Boxes[box], owned)
+	return fmt.Sprintf("PC box %d", box+1), nil
+}
+
+// lookupOwned resolves a command argument to an owned Pokémon, printing a message if there is none.
+func lookupOwned(configPtr *config, ref string) (*ownedPokemon, bool) {
+	owned, ok := findOwned(configPtr, ref)
+	if !ok {
+		color.New(color.FgHiRed, color.Bold).Printf("You don't have a Pokémon called %v (use its #ID if you own several).\n", ref)
+	}
+	return owned, ok
+}
+
+// printOwnedLine prints a one-line summary of an owned Pokémon, prefixed with its slot.
+func printOwnedLine(slot int, owned *ownedPokemon, pokedex map[string]pokemonDetails) {
+	color.New(color.Bold).Printf("  %d. ", slot)
+	color.New(color.FgHiYellow, color.Bold).Printf("#%d %v", owned.ID, owned.displayName())
+	if owned.Nickname != "" {
+		color.New(color.FgWhite).Printf(" (%v)", owned.Species)
+	}
+	color.New(color.Bold).Printf("  Lv. %d", owned.Level)
+	if details, ok := pokedex[owned.Species]; ok {
+		color.New(color.FgHiGreen).Printf("  HP %d/%d", owned.currentHP(details), owned.actualStats(details)["hp"])
+	}
+	if owned.Shiny {
+		color.New(color.FgHiYellow, color.Bold).Print("  ★")
+	}
+	fmt.Println()
+}
+
+// party lists the Pokémon the trainer is carrying.
+func party(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	if len(configPtr.Party) == 0 {
+		color.New(color.FgHiMagenta, color.Bold).Println("Your party is empty... go catch something!")
+		return nil
+	}
+	color.New(color.FgCyan, color.Bold).Printf("Your party (%d/%d):\n", len(configPtr.Party), maxPartySize)
+	for i, owned := range configPtr.Party {
+		printOwnedLine(i+1, owned, pokedex)
+	}
+	return nil
+}
+
+// box lists the contents of a PC box (box 1 by default) and how full every box is.
+func box(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	number := 1
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 || n > numBoxes {
+			color.New(color.FgHiRed, color.Bold).Printf("Box must be a number from 1 to %d.\n", numBoxes)
+			return nil
+		}
+		number = n
+	}
+	configPtr.boxWithRoom() // Make sure all boxes exist
+
+	color.New(color.FgCyan, color.Bold).Printf("PC box %d (%d/%d):\n", number, len(configPtr.Boxes[number-1]), boxCapacity)
+	if len(configPtr.Boxes[number-1]) == 0 {
+		color.New(color.FgHiBlack).Println("  (empty)")
+	}
+	for i, owned := range configPtr.Boxes[number-1] {
+		printOwnedLine(i+1, owned, pokedex)
+	}
+
+	color.New(color.FgHiBlack).Print("Boxes:")
+	for b, pcBox := range configPtr.Boxes {
+		color.New(color.FgHiBlack).Printf(" [%d: %d]", b+1, len(pcBox))
+	}
+	fmt.Println()
+	return nil
+}
+
+// deposit moves a party Pokémon into the first PC box with room.
+func deposit(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	if busyInBattle(configPtr) {
+		return nil
+	}
+	if len(args) == 0 {
+		color.New(color.FgHiRed, color.Bold).Println("Error: missing pokemon argument.")
+		return nil
+	}
+	owned, ok := lookupOwned(configPtr, args[0])
+	if !ok {
+		return nil
+	}
+	if b, _, _ := configPtr.locateOwned(owned); b != -1 {
+		color.New(color.FgHiRed, color.Bold).Printf("%v is already in PC box %d.\n", owned.displayName(), b+1)
+		return nil
+	}
+	if len(configPtr.Party) == 1 {
+		color.New(color.FgHiRed, color.Bold).Println("You can't deposit your last party Pokémon!")
+		return nil
+	}
+	target := configPtr.boxWithRoom()
+	if target == -1 {
+		return fmt.Errorf("every PC box is full")
+	}
+
+	configPtr.removeOwned(owned)
+	configPtr.Boxes[target] = append(configPtr.Boxes[target], owned)
+	configPtr.unsaved = true
+	color.New(color.FgHiGreen, color.Bold).Printf("%v was deposited in PC box %d.\n", owned.displayName(), target+1)
+	return nil
+}
+
+// withdraw moves a boxed Pokémon into the party, if there is room.
+func withdraw(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	if busyInBattle(configPtr) {
+		return nil
+	}
+	if len(args) == 0 {
+		color.New(color.FgHiRed, color.Bold).Println("Error: missing pokemon argument.")
+		return nil
+	}
+	owned, ok := lookupOwned(configPtr, args[0])
+	if !ok {
+		return nil
+	}
+	if b, _, _ := configPtr.locateOwned(owned); b == -1 {
+		color.New(color.FgHiRed, color.Bold).Printf("%v is already in your party.\n", owned.displayName())
+		return nil
+	}
+	if len(configPtr.Party) >= maxPartySize {
+		color.New(color.FgHiRed, color.Bold).Println("Your party is full. Deposit or swap a Pokémon first.")
+		return nil
+	}
+
+	configPtr.removeOwned(owned)
+	configPtr.Party = append(configPtr.Party, owned)
+	configPtr.unsaved = true
+	color.New(color.FgHiGreen, color.Bold).Printf("%v joined your party.\n", owned.displayName())
+	return nil
+}
+
+// swap exchanges the places of two owned Pokémon, e.g. to reorder the party
+// or to trade a party member for one in the PC.
+func swap(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	if busyInBattle(configPtr) {
+		return nil
+	}
+	if len(args) < 2 {
+		color.New(color.FgHiRed, color.Bold).Println("Error: swap needs two pokemon arguments.")
+		return nil
+	}
+	first, ok := lookupOwned(configPtr, args[0])
+	if !ok {
+		return nil
+	}
+	second, ok := lookupOwned(configPtr, args[1])
+	if !ok {
+		return nil
+	}
+
+	firstBox, firstIndex, _ := configPtr.locateOwned(first)
+	secondBox, secondIndex, _ := configPtr.locateOwned(second)
+	slotOf := func(box int) []*ownedPokemon {
+		if box == -1 {
+			return configPtr.Party
+		}
+		return configPtr.Boxes[box]
+	}
+	slotOf(firstBox)[firstIndex], slotOf(secondBox)[secondIndex] = second, first
+	configPtr.unsaved = true
+
+	color.New(color.FgHiGreen, color.Bold).Printf("Swapped %v and %v.\n", first.displayName(), second.displayName())
+	return nil
+}
+
+// release sets an owned Pokémon free. It stays registered as caught in the pokedex.
+func release(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	if busyInBattle(configPtr) {
+		return nil
+	}
+	if len(args) == 0 {
+		color.New(color.FgHiRed, color.Bold).Println("Error: missing pokemon argument.")
+		return nil
+	}
+	owned, ok := lookupOwned(configPtr, args[0])
+	if !ok {
+		return nil
+	}
+	if b, _, _ := configPtr.locateOwned(owned); b == -1 && len(configPtr.Party) == 1 {
+		color.New(color.FgHiRed, color.Bold).Println("You can't release your last party Pokémon!")
+		return nil
+	}
+
+	configPtr.removeOwned(owned)
+	configPtr.unsaved = true
+	color.New(color.FgHiMagenta, color.Bold).Printf("%v was released. Bye-bye, %v!\n", owned.displayName(), owned.displayName())
+	return nil
+}
+
+// nickname gives an owned Pokémon a nickname, or clears it when no name is given.
+func nickname(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	if len(args) == 0 {
+		color.New(color.FgHiRed, color.Bold).Println("Error: usage is nickname <pokemon> [name].")
+		return nil
+	}
+	owned, ok := lookupOwned(configPtr, args[0])
+	if !ok {
+		return nil
+	}
+
+	name := ""
+	if len(args) > 1 {
+		name = args[1]
+	}
+	// Numbers are reserved for IDs, and nicknames must point to a single Pokémon.
+	if _, err := strconv.Atoi(name); err == nil || (len(name) > 0 && name[0] == '#') {
+		color.New(color.FgHiRed, color.Bold).Println("A nickname can't be a number.")
+		return nil
+	}
+	for _, other := range configPtr.allOwned() {
+		if name != "" && other != owned && other.Nickname == name {
+			color.New(color.FgHiRed, color.Bold).Printf("#%d is already called %v.\n", other.ID, name)
+			return nil
+		}
+	}
+
+	oldName := owned.displayName()
+	owned.Nickname = name
+	configPtr.unsaved = true
+	if name == "" {
+		color.New(color.FgHiGreen, color.Bold).Printf("%v's nickname was cleared.\n", oldName)
+	} else {
+		color.New(color.FgHiGreen, color.Bold).Printf("%v is now called %v.\n", oldName, name)
+	}
+	return nil
+}
+
+// markSeen registers a species as seen in the wild.
+func (configPtr *config) markSeen(species string) {
+	if configPtr.Seen == nil {
+		configPtr.Seen = make(map[string]bool)
+	}
+	if !configPtr.Seen[species] {
+		configPtr.Seen[species] = true
+		configPtr.unsaved = true
+	}
+}
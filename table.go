@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+)
+
+// printTable prints rows as left-aligned columns under a bold header.
+// cellColor may pick a color per cell; it can be nil, and may return nil for plain text.
+func printTable(headers []string, rows [][]string, cellColor func(row, col int) *color.Color) {
+	widths := make([]int, len(headers))
+	for col, header := range headers {
+		widths[col] = utf8.RuneCountInString(header)
+	}
+	for _, row := range rows {
+		for col, cell := range row {
+			if col < len(widths) && utf8.RuneCountInString(cell) > widths[col] {
+				widths[col] = utf8.RuneCountInString(cell)
+			}
+		}
+	}
+
+	// Pad before coloring so escape codes don't throw the alignment off.
+	pad := func(text string, width int) string {
+		return text + strings.Repeat(" ", width-utf8.RuneCountInString(text))
+	}
+
+	headerColor := color.New(color.FgCyan, color.Bold)
+	for col, header := range headers {
+		headerColor.Print(pad(header, widths[col]) + "  ")
+	}
+	fmt.Println()
+
+	for r, row := range rows {
+		for col, cell := range row {
+			if col >= len(widths) {
+				break
+			}
+			text := pad(cell, widths[col]) + "  "
+			var cellColorPtr *color.Color
+			if cellColor != nil {
+				cellColorPtr = cellColor(r, col)
+			}
+			if cellColorPtr == nil {
+				fmt.Print(text)
+			} else {
+				cellColorPtr.Print(text)
+			}
+		}
+		fmt.Println()
+	}
+}
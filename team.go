@@ -0,0 +1,500 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
+	"github.com/fatih/color"
+)
+
+// maxTeamSize is how many Pokémon a team holds, the same as a party.
+const maxTeamSize = maxPartySize
+
+// typeNames lists the 18 types in the games' usual order.
+var typeNames = []string{
+	"normal", "fire", "water", "electric", "grass", "ice", "fighting", "poison", "ground",
+	"flying", "psychic", "bug", "rock", "ghost", "dragon", "dark", "steel", "fairy",
+}
+
+// teamMember is one Pokémon of the team being built. It is either an owned
+// Pokémon or just a Pokémon name, so teams can be planned before catching anything.
+type teamMember struct {
+	Pokemon string   `json:"pokemon"`            // Pokémon name, e.g. "garchomp"
+	OwnedID int      `json:"owned_id,omitempty"` // ID of the owned Pokémon, or 0 when added by name
+	Moves   []string `json:"moves,omitempty"`    // Moves for the coverage analysis; an owned Pokémon's own moves if empty
+
+	// The rest of a Showdown set; owned Pokémon use their own nickname, level, nature, IVs and EVs.
+	Nickname string         `json:"nickname,omitempty"`
+	Gender   string         `json:"gender,omitempty"`
+	Item     string         `json:"item,omitempty"`
+	Ability  string         `json:"ability,omitempty"`
+	Level    int            `json:"level,omitempty"`
+	Shiny    bool           `json:"shiny,omitempty"`
+	Nature   string         `json:"nature,omitempty"`
+	EVs      map[string]int `json:"evs,omitempty"`
+	IVs      map[string]int `json:"ivs,omitempty"`
+}
+
+// teamEntry is a team member with everything the analysis needs.
+type teamEntry struct {
+	member  *teamMember
+	owned   *ownedPokemon // nil when added by name, or when the owned Pokémon is gone
+	details pokemonDetails
+	types   []string
+	moves   []string
+}
+
+// label names a team member in tables, by nickname for owned Pokémon.
+func (entry teamEntry) label() string {
+	if entry.owned != nil {
+		return entry.owned.displayName()
+	}
+	if entry.member.Nickname != "" {
+		return entry.member.Nickname
+	}
+	return entry.member.Pokemon
+}
+
+// canLearn reports whether a Pokémon learns a move in any game.
+func canLearn(details pokemonDetails, moveName string) bool {
+	for _, move := range details.Moves {
+		if move.Move.Name == moveName {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchTypeChart returns the damage relations of all 18 types.
+// Types never change, so the chart is kept for the session.
+func fetchTypeChart(configPtr *config, cachePtr *internal.Cache) (map[string]typeDetails, error) {
+	if configPtr.typeChart != nil {
+		return configPtr.typeChart, nil
+	}
+	chart := make(map[string]typeDetails)
+	for _, name := range typeNames {
+		details, err := fetchType(cachePtr, name)
+		if err != nil {
+			return nil, err
+		}
+		chart[name] = details
+	}
+	configPtr.typeChart = chart
+	return chart, nil
+}
+
+// lookupTeamPokemon finds the Pokémon to add to the team: an owned Pokémon by #ID or
+// nickname, or any Pokémon by name. It reports false after printing a message when
+// nothing is found.
+func lookupTeamPokemon(configPtr *config, cachePtr *internal.Cache, ref string, pokedex map[string]pokemonDetails) (teamMember, pokemonDetails, bool, error) {
+	if owned, found := findOwned(configPtr, ref); found && owned.Species != ref {
+		return teamMember{Pokemon: owned.Species, OwnedID: owned.ID}, pokedex[owned.Species], true, nil
+	}
+
+	details, ok := pokedex[ref]
+	if !ok {
+		var err error
+		details, err = fetchPokemon(cachePtr, ref)
+		if err != nil {
+			return teamMember{}, details, false, reportUnknown(configPtr, cachePtr, err, "pokemon", ref)
+		}
+	}
+	return teamMember{Pokemon: details.Name}, details, true, nil
+}
+
+// loadTeam gathers the data of every team member.
+func loadTeam(configPtr *config, cachePtr *internal.Cache, pokedex map[string]pokemonDetails) ([]teamEntry, error) {
+	entries := []teamEntry{}
+	for _, member := range configPtr.Team {
+		entry := teamEntry{member: member, moves: member.Moves}
+		if member.OwnedID != 0 {
+			if owned, found := findOwned(configPtr, "#"+strconv.Itoa(member.OwnedID)); found {
+				entry.owned = owned
+				// An owned Pokémon may have evolved since it joined the team.
+				member.Pokemon = owned.Species
+				if len(entry.moves) == 0 {
+					entry.moves = owned.Moves
+				}
+			}
+		}
+
+		details, ok := pokedex[member.Pokemon]
+		if !ok {
+			var err error
+			details, err = fetchPokemon(cachePtr, member.Pokemon)
+			if err != nil {
+				return nil, err
+			}
+		}
+		entry.details = details
+		for _, t := range details.Types {
+			entry.types = append(entry.types, t.Type.Name)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// defenseRow is how each team member takes hits of one attacking type.
+type defenseRow struct {
+	attackType  string
+	multipliers []float64
+}
+
+// counts returns how many members are weak to the type and how many resist it or are immune.
+func (row defenseRow) counts() (int, int) {
+	weak, resist := 0, 0
+	for _, multiplier := range row.multipliers {
+		if multiplier > 1 {
+			weak++
+		} else if multiplier < 1 {
+			resist++
+		}
+	}
+	return weak, resist
+}
+
+// defensiveWarnings points out the types that threaten several members at once.
+func defensiveWarnings(rows []defenseRow) []string {
+	warnings := []string{}
+	for _, row := range rows {
+		weak, resist := row.counts()
+		switch {
+		case weak >= 3:
+			warnings = append(warnings, fmt.Sprintf("%d members weak to %v", weak, row.attackType))
+		case weak >= 2 && resist == 0:
+			warnings = append(warnings, fmt.Sprintf("%d members weak to %v and none resist it", weak, row.attackType))
+		}
+	}
+	return warnings
+}
+
+// formatMultiplier shows a damage multiplier the way type charts do; neutral damage is left blank.
+func formatMultiplier(multiplier float64) string {
+	switch multiplier {
+	case 1:
+		return ""
+	case 0:
+		return "0"
+	case 0.5:
+		return "½"
+	case 0.25:
+		return "¼"
+	default:
+		return fmt.Sprintf("%gx", multiplier)
+	}
+}
+
+// uncoveredTypes returns the types none of the attacking types hits super effectively.
+func uncoveredTypes(chart map[string]typeDetails, attackTypes []string) []string {
+	uncovered := []string{}
+	for _, defending := range typeNames {
+		covered := false
+		for _, attacking := range attackTypes {
+			if typeMultiplier(chart[attacking], []string{defending}) > 1 {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			uncovered = append(uncovered, defending)
+		}
+	}
+	return uncovered
+}
+
+// team builds a team of up to six Pokémon and analyses its type matchups.
+//
+//	team                          show the team and its analysis
+//	team add <pokemon|#id> [moves...]  add a Pokémon, optionally with the moves it runs
+//	team moves <slot> <moves...>  set the moves of a member
+//	team remove <slot>            take a member off the team
+//	team party                    start from the current party
+//	team clear                    empty the team
+//	team export [file]            print or write the team as a Showdown paste
+//	team import <file>            replace the team with a Showdown paste
+func team(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	if len(args) == 0 || args[0] == "show" {
+		return showTeam(configPtr, cachePtr, pokedex)
+	}
+
+	switch args[0] {
+	case "add":
+		if len(args) < 2 {
+			color.New(color.FgHiRed, color.Bold).Println("Usage: team add <pokemon|#id> [moves...]")
+			return nil
+		}
+		if len(configPtr.Team) >= maxTeamSize {
+			color.New(color.FgHiRed, color.Bold).Printf("The team is full (%d/%d). Remove someone first.\n", maxTeamSize, maxTeamSize)
+			return nil
+		}
+		member, details, found, err := lookupTeamPokemon(configPtr, cachePtr, args[1], pokedex)
+		if err != nil || !found {
+			return err
+		}
+		if !setTeamMoves(cachePtr, &member, details, args[2:]) {
+			return nil
+		}
+		configPtr.Team = append(configPtr.Team, &member)
+		configPtr.unsaved = true
+		color.New(color.FgHiGreen, color.Bold).Printf("%v joined the team (%d/%d).\n", args[1], len(configPtr.Team), maxTeamSize)
+		return nil
+
+	case "moves":
+		slot, ok := teamSlot(configPtr, args)
+		if !ok {
+			return nil
+		}
+		member := configPtr.Team[slot]
+		details, found := pokedex[member.Pokemon]
+		if !found {
+			var err error
+			details, err = fetchPokemon(cachePtr, member.Pokemon)
+			if err != nil {
+				return err
+			}
+		}
+		if !setTeamMoves(cachePtr, member, details, args[2:]) {
+			return nil
+		}
+		configPtr.unsaved = true
+		color.New(color.FgHiGreen, color.Bold).Printf("Moves of %v set.\n", member.Pokemon)
+		return nil
+
+	case "remove":
+		slot, ok := teamSlot(configPtr, args)
+		if !ok {
+			return nil
+		}
+		removed := configPtr.Team[slot]
+		configPtr.Team = append(configPtr.Team[:slot], configPtr.Team[slot+1:]...)
+		configPtr.unsaved = true
+		color.New(color.FgCyan, color.Bold).Printf("%v left the team.\n", removed.Pokemon)
+		return nil
+
+	case "party":
+		configPtr.Team = nil
+		for _, owned := range configPtr.Party {
+			configPtr.Team = append(configPtr.Team, &teamMember{Pokemon: owned.Species, OwnedID: owned.ID})
+		}
+		configPtr.unsaved = true
+		color.New(color.FgCyan, color.Bold).Printf("The team is now your party (%d Pokémon).\n", len(configPtr.Team))
+		return nil
+
+	case "clear":
+		configPtr.Team = nil
+		configPtr.unsaved = true
+		color.New(color.FgCyan, color.Bold).Println("The team is empty.")
+		return nil
+
+	case "export":
+		return teamExport(configPtr, cachePtr, args[1:], pokedex)
+
+	case "import":
+		return teamImport(configPtr, cachePtr, args[1:], pokedex)
+	}
+
+	color.New(color.FgHiRed, color.Bold).Printf("Unknown team command %v. Use add, moves, remove, party, clear, export or import.\n", args[0])
+	return nil
+}
+
+// teamSlot parses the slot number of team moves/remove, printing a message when it is invalid.
+func teamSlot(configPtr *config, args []string) (int, bool) {
+	if len(args) < 2 {
+		color.New(color.FgHiRed, color.Bold).Printf("Usage: team %v <slot>\n", args[0])
+		return 0, false
+	}
+	slot, err := strconv.Atoi(args[1])
+	if err != nil || slot < 1 || slot > len(configPtr.Team) {
+		color.New(color.FgHiRed, color.Bold).Printf("Slot must be a number from 1 to %d.\n", len(configPtr.Team))
+		return 0, false
+	}
+	return slot - 1, true
+}
+
+// setTeamMoves checks that the Pokémon can learn the moves and stores them on the member.
+// It reports false after printing a message when a move doesn't fit.
+func setTeamMoves(cachePtr *internal.Cache, member *teamMember, details pokemonDetails, moves []string) bool {
+	if len(moves) > 4 {
+		color.New(color.FgHiRed, color.Bold).Println("A Pokémon can only know four moves.")
+		return false
+	}
+	for _, move := range moves {
+		if !canLearn(details, move) {
+			color.New(color.FgHiRed, color.Bold).Printf("%v can't learn %v.\n", details.Name, move)
+			printDidYouMean(similarNames(move, learnableMoves(details)))
+			return false
+		}
+	}
+	member.Moves = moves
+	return true
+}
+
+// learnableMoves lists every move a Pokémon learns in any game.
+func learnableMoves(details pokemonDetails) []string {
+	names := []string{}
+	for _, move := range details.Moves {
+		names = append(names, move.Move.Name)
+	}
+	return names
+}
+
+// showTeam prints the team's stats, defensive type matrix, offensive coverage and warnings.
+func showTeam(configPtr *config, cachePtr *internal.Cache, pokedex map[string]pokemonDetails) error {
+	if len(configPtr.Team) == 0 {
+		color.New(color.FgHiMagenta, color.Bold).Println("Your team is empty. Add Pokémon with team add <pokemon|#id> [moves...] or team party.")
+		return nil
+	}
+
+	entries, err := loadTeam(configPtr, cachePtr, pokedex)
+	if err != nil {
+		return err
+	}
+	chart, err := fetchTypeChart(configPtr, cachePtr)
+	if err != nil {
+		return err
+	}
+
+	// Members and their base stats
+	color.New(color.FgCyan, color.Bold).Printf("Your team (%d/%d):\n", len(entries), maxTeamSize)
+	statHeaders := []string{"#", "Pokémon", "Types", "HP", "Atk", "Def", "SpA", "SpD", "Spe", "Total"}
+	rows := [][]string{}
+	sums := make([]int, len(internal.StatNames)+1)
+	for i, entry := range entries {
+		row := []string{strconv.Itoa(i + 1), entry.label(), strings.Join(entry.types, "/")}
+		base := make(map[string]int)
+		for _, value := range entry.details.Stats {
+			base[value.Stat.Name] = value.BaseStat
+		}
+		total := 0
+		for s, stat := range internal.StatNames {
+			row = append(row, strconv.Itoa(base[stat]))
+			sums[s] += base[stat]
+			total += base[stat]
+		}
+		sums[len(internal.StatNames)] += total
+		rows = append(rows, append(row, strconv.Itoa(total)))
+	}
+	average := []string{"", "average", ""}
+	for _, sum := range sums {
+		average = append(average, strconv.Itoa(sum/len(entries)))
+	}
+	rows = append(rows, average)
+	printTable(statHeaders, rows, func(row, col int) *color.Color {
+		if row == len(entries) {
+			return color.New(color.FgHiBlack)
+		}
+		if col == 1 {
+			return color.New(color.Bold)
+		}
+		return nil
+	})
+
+	// Defensive matrix: how hard each attacking type hits each member
+	fmt.Println()
+	color.New(color.FgCyan, color.Bold).Println("Defense (damage taken):")
+	defense := []defenseRow{}
+	for _, attackType := range typeNames {
+		row := defenseRow{attackType: attackType}
+		for _, entry := range entries {
+			row.multipliers = append(row.multipliers, typeMultiplier(chart[attackType], entry.types))
+		}
+		defense = append(defense, row)
+	}
+	matrixHeaders := []string{"Attack"}
+	for _, entry := range entries {
+		matrixHeaders = append(matrixHeaders, entry.label())
+	}
+	matrixHeaders = append(matrixHeaders, "Weak", "Resist")
+	matrix := [][]string{}
+	for _, row := range defense {
+		cells := []string{row.attackType}
+		for _, multiplier := range row.multipliers {
+			cells = append(cells, formatMultiplier(multiplier))
+		}
+		weak, resist := row.counts()
+		matrix = append(matrix, append(cells, strconv.Itoa(weak), strconv.Itoa(resist)))
+	}
+	printTable(matrixHeaders, matrix, func(row, col int) *color.Color {
+		if col == 0 {
+			return color.New(color.FgCyan)
+		}
+		if col > len(entries) {
+			return color.New(color.FgHiBlack)
+		}
+		multiplier := defense[row].multipliers[col-1]
+		switch {
+		case multiplier == 0:
+			return color.New(color.FgHiCyan, color.Bold)
+		case multiplier > 1:
+			return color.New(color.FgHiRed, color.Bold)
+		case multiplier < 1:
+			return color.New(color.FgHiGreen)
+		}
+		return nil
+	})
+
+	// Offensive coverage from the damaging moves of the team
+	fmt.Println()
+	color.New(color.FgCyan, color.Bold).Println("Offense (super-effective coverage):")
+	attackTypes := []string{}
+	seen := map[string]bool{}
+	withoutMoves := []string{}
+	for _, entry := range entries {
+		if len(entry.moves) == 0 {
+			withoutMoves = append(withoutMoves, entry.label())
+		}
+		for _, moveName := range entry.moves {
+			details, err := fetchMove(cachePtr, moveName)
+			if err != nil {
+				return err
+			}
+			if details.DamageClass.Name == "status" || seen[details.Type.Name] {
+				continue
+			}
+			seen[details.Type.Name] = true
+			attackTypes = append(attackTypes, details.Type.Name)
+		}
+	}
+	uncovered := uncoveredTypes(chart, attackTypes)
+	if len(attackTypes) == 0 {
+		color.New(color.FgHiBlack).Println("  No damaging moves chosen yet. Use team moves <slot> <moves...>.")
+	} else {
+		color.New(color.Bold).Printf("  Attacking types: %v\n", strings.Join(attackTypes, ", "))
+		covered := []string{}
+		for _, t := range typeNames {
+			if !contains(uncovered, t) {
+				covered = append(covered, t)
+			}
+		}
+		color.New(color.FgHiGreen).Printf("  Super effective against (%d/%d): %v\n", len(covered), len(typeNames), strings.Join(covered, ", "))
+	}
+	if len(withoutMoves) > 0 {
+		color.New(color.FgHiBlack).Printf("  Without moves: %v\n", strings.Join(withoutMoves, ", "))
+	}
+
+	// Warnings
+	warnings := defensiveWarnings(defense)
+	if len(attackTypes) > 0 && len(uncovered) > 0 {
+		warnings = append(warnings, fmt.Sprintf("no super-effective move against %v", strings.Join(uncovered, ", ")))
+	}
+	if len(warnings) > 0 {
+		fmt.Println()
+		for _, warning := range warnings {
+			color.New(color.FgHiYellow, color.Bold).Printf("⚠ %v\n", warning)
+		}
+	}
+	return nil
+}
+
+// contains reports whether names holds name.
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// testGroundType is the damage relations of the ground type, as the PokeAPI returns them.
+const testGroundType = `{"name": "ground", "damage_relations": {
+	"double_damage_to": [{"name": "poison"}, {"name": "rock"}, {"name": "steel"}, {"name": "fire"}, {"name": "electric"}],
+	"half_damage_to": [{"name": "bug"}, {"name": "grass"}],
+	"no_damage_to": [{"name": "flying"}]
+}}`
+
+// TestTypeMultiplier checks single types, dual types and immunities.
+func TestTypeMultiplier(t *testing.T) {
+	var ground typeDetails
+	if err := json.Unmarshal([]byte(testGroundType), &ground); err != nil {
+		t.Fatalf("could not parse test type: %v", err)
+	}
+
+	cases := []struct {
+		defending []string
+		expected  float64
+	}{
+		{defending: []string{"fire"}, expected: 2},
+		{defending: []string{"rock", "steel"}, expected: 4},
+		{defending: []string{"fire", "grass"}, expected: 1},
+		{defending: []string{"grass", "bug"}, expected: 0.25},
+		{defending: []string{"electric", "flying"}, expected: 0},
+		{defending: []string{"water"}, expected: 1},
+	}
+	for _, c := range cases {
+		if actual := typeMultiplier(ground, c.defending); actual != c.expected {
+			t.Errorf("ground vs %v: Expected %v, Got %v", c.defending, c.expected, actual)
+		}
+	}
+
+	chart := map[string]typeDetails{"ground": ground}
+	uncovered := uncoveredTypes(chart, []string{"ground"})
+	if len(uncovered) != len(typeNames)-5 {
+		t.Errorf("Expected %d types without coverage, Got %v", len(typeNames)-5, uncovered)
+	}
+	for _, name := range uncovered {
+		if name == "fire" || name == "steel" {
+			t.Errorf("Expected %v to be covered by ground, Got %v", name, uncovered)
+		}
+	}
+}
+
+// TestDefensiveWarnings checks which shared weaknesses are warned about.
+func TestDefensiveWarnings(t *testing.T) {
+	rows := []defenseRow{
+		{attackType: "ground", multipliers: []float64{2, 2, 4, 1, 0}},
+		{attackType: "ice", multipliers: []float64{2, 2, 1, 1, 1}},
+		{attackType: "fire", multipliers: []float64{2, 2, 0.5, 1, 1}},
+		{attackType: "water", multipliers: []float64{2, 1, 1, 1, 1}},
+	}
+	expected := []string{
+		"3 members weak to ground",
+		"2 members weak to ice and none resist it",
+	}
+	actual := defensiveWarnings(rows)
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected %v, Got %v", expected, actual)
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("Expected %q, Got %q", expected[i], actual[i])
+		}
+	}
+}
+
+// TestFormatMultiplier checks the type chart notation.
+func TestFormatMultiplier(t *testing.T) {
+	cases := map[float64]string{1: "", 0: "0", 0.5: "½", 0.25: "¼", 2: "2x", 4: "4x"}
+	for multiplier, expected := range cases {
+		if actual := formatMultiplier(multiplier); actual != expected {
+			t.Errorf("%v: Expected %q, Got %q", multiplier, expected, actual)
+		}
+	}
+}
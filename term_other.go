@@ -0,0 +1,26 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+package main
+
+import (
+	"errors"
+	"os"
+)
+
+// errNoTerminal is returned where full-screen mode can't control the terminal.
+var errNoTerminal = errors.New("full-screen mode is not supported on this system")
+
+// makeRaw is not supported on this system.
+func makeRaw(fd int) (func(), error) {
+	return nil, errNoTerminal
+}
+
+// resizeSignals is empty, as this system doesn't tell about resizes.
+func resizeSignals() []os.Signal {
+	return nil
+}
+
+// terminalSize is not supported on this system.
+func terminalSize(fd int) (int, int, error) {
+	return 0, 0, errNoTerminal
+}
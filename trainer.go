@@ -0,0 +1,268 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
+	"github.com/fatih/color"
+)
+
+// maxIdle is the longest gap between two commands that counts as play time,
+// so leaving the game open overnight doesn't.
+const maxIdle = 5 * time.Minute
+
+// trainerProfile is the trainer's record of play, kept in the save file.
+type trainerProfile struct {
+	Name         string               `json:"name"`
+	Started      time.Time            `json:"started"`
+	PlayTime     time.Duration        `json:"play_time"`    // Stored in nanoseconds
+	Throws       int                  `json:"throws"`       // Pokéballs thrown, in and out of battle
+	Catches      int                  `json:"catches"`      // Throws that caught the Pokémon
+	Misses       int                  `json:"misses"`       // Throws it broke free from
+	Explored     map[string]bool      `json:"explored"`     // Location areas explored
+	Achievements map[string]time.Time `json:"achievements"` // Unlock time by achievement ID
+}
+
+// achievement is a goal to work toward. progress returns how far the trainer
+// is and the goal; it is unlocked once the first reaches the second.
+type achievement struct {
+	ID          string
+	Name        string
+	Description string
+	progress    func(configPtr *config, pokedex map[string]pokemonDetails) (int, int)
+}
+
+// achievements lists every achievement in the order the achievements command shows them.
+var achievements = []achievement{
+	{ID: "first-catch", Name: "Gotcha!", Description: "Catch your first Pokémon", progress: func(configPtr *config, pokedex map[string]pokemonDetails) (int, int) {
+		return len(pokedex), 1
+	}},
+	{ID: "pitcher", Name: "Pitcher", Description: "Throw 100 Pokéballs", progress: func(configPtr *config, pokedex map[string]pokemonDetails) (int, int) {
+		return configPtr.Trainer.Throws, 100
+	}},
+	{ID: "kanto-starters", Name: "Kanto Starters", Description: "Catch Bulbasaur, Charmander and Squirtle", progress: caughtAll("bulbasaur", "charmander", "squirtle")},
+	{ID: "johto-starters", Name: "Johto Starters", Description: "Catch Chikorita, Cyndaquil and Totodile", progress: caughtAll("chikorita", "cyndaquil", "totodile")},
+	{ID: "hoenn-starters", Name: "Hoenn Starters", Description: "Catch Treecko, Torchic and Mudkip", progress: caughtAll("treecko", "torchic", "mudkip")},
+	{ID: "full-party", Name: "Full Party", Description: "Have six Pokémon in your party", progress: func(configPtr *config, pokedex map[string]pokemonDetails) (int, int) {
+		return len(configPtr.Party), 6
+	}},
+	{ID: "explorer", Name: "Explorer", Description: "Explore 10 areas", progress: func(configPtr *config, pokedex map[string]pokemonDetails) (int, int) {
+		return len(configPtr.Trainer.Explored), 10
+	}},
+	{ID: "globetrotter", Name: "Globetrotter", Description: "Explore 50 areas", progress: func(configPtr *config, pokedex map[string]pokemonDetails) (int, int) {
+		return len(configPtr.Trainer.Explored), 50
+	}},
+	{ID: "shiny", Name: "Shiny!", Description: "Own a shiny Pokémon", progress: func(configPtr *config, pokedex map[string]pokemonDetails) (int, int) {
+		for _, owned := range configPtr.allOwned() {
+			if owned.Shiny {
+				return 1, 1
+			}
+		}
+		return 0, 1
+	}},
+	{ID: "legendary", Name: "Legend", Description: "Catch a legendary or mythical Pokémon", progress: func(configPtr *config, pokedex map[string]pokemonDetails) (int, int) {
+		// Worked out from the Pokédex, so catches from before achievements existed count too
+		for id := range caughtSpecies(pokedex) {
+			if internal.IsLegendary(id) {
+				return 1, 1
+			}
+		}
+		return 0, 1
+	}},
+	{ID: "collector", Name: "Collector", Description: "Catch 50 different species", progress: func(configPtr *config, pokedex map[string]pokemonDetails) (int, int) {
+		return len(pokedex), 50
+	}},
+	{ID: "kanto-dex", Name: "Kanto Complete", Description: "Catch all 151 Kanto species", progress: func(configPtr *config, pokedex map[string]pokemonDetails) (int, int) {
+		kanto := internal.Generations[0]
+		caught := 0
+		for id := range caughtSpecies(pokedex) {
+			if id >= kanto.First && id <= kanto.Last {
+				caught++
+			}
+		}
+		return caught, kanto.Last - kanto.First + 1
+	}},
+}
+
+// caughtAll is the progress of an achievement for catching every one of species.
+func caughtAll(species ...string) func(configPtr *config, pokedex map[string]pokemonDetails) (int, int) {
+	return func(configPtr *config, pokedex map[string]pokemonDetails) (int, int) {
+		caught := 0
+		for _, name := range species {
+			if _, ok := pokedex[name]; ok {
+				caught++
+			}
+		}
+		return caught, len(species)
+	}
+}
+
+// caughtSpecies returns the national dex numbers of the caught species.
+func caughtSpecies(pokedex map[string]pokemonDetails) map[int]bool {
+	caught := make(map[int]bool)
+	for _, details := range pokedex {
+		caught[speciesNumber(details)] = true
+	}
+	return caught
+}
+
+// tickPlayTime adds the time since the previous command to the play time, up to maxIdle.
+// It is called before every command; a new profile starts on the first one.
+// The play time is only kept in memory: it is saved along with the next change,
+// or by savePlayTime when the session ends, so idle commands don't rewrite the save.
+func (configPtr *config) tickPlayTime(now time.Time) {
+	if configPtr.Trainer.Started.IsZero() {
+		configPtr.Trainer.Started = now
+		configPtr.playTimeDue = true
+	}
+	if !configPtr.lastActive.IsZero() {
+		configPtr.Trainer.PlayTime += min(now.Sub(configPtr.lastActive), maxIdle)
+		configPtr.playTimeDue = true
+	}
+	configPtr.lastActive = now
+}
+
+// savePlayTime saves the game if play time was counted since the last save,
+// so that it isn't lost when the session ends.
+func savePlayTime(savePath string, configPtr *config, pokedex map[string]pokemonDetails) {
+	if !configPtr.playTimeDue {
+		return
+	}
+	if err := writeSave(savePath, configPtr, pokedex); err != nil {
+		fmt.Printf("Error saving game: %v\n", err)
+	}
+}
+
+// recordThrow counts a thrown Pokéball in the trainer profile.
+func (configPtr *config) recordThrow(caught bool) {
+	configPtr.Trainer.Throws++
+	if caught {
+		configPtr.Trainer.Catches++
+	} else {
+		configPtr.Trainer.Misses++
+	}
+	configPtr.unsaved = true
+}
+
+// recordExplored counts a location area as explored in the trainer profile.
+func (configPtr *config) recordExplored(area string) {
+	if configPtr.Trainer.Explored == nil {
+		configPtr.Trainer.Explored = make(map[string]bool)
+	}
+	if !configPtr.Trainer.Explored[area] {
+		configPtr.Trainer.Explored[area] = true
+		configPtr.unsaved = true
+	}
+}
+
+// checkAchievements unlocks every achievement whose goal has been reached since
+// the last check, and returns them.
+func checkAchievements(configPtr *config, pokedex map[string]pokemonDetails, now time.Time) []achievement {
+	unlocked := []achievement{}
+	for _, a := range achievements {
+		if _, done := configPtr.Trainer.Achievements[a.ID]; done {
+			continue
+		}
+		if current, goal := a.progress(configPtr, pokedex); current >= goal {
+			if configPtr.Trainer.Achievements == nil {
+				configPtr.Trainer.Achievements = make(map[string]time.Time)
+			}
+			configPtr.Trainer.Achievements[a.ID] = now
+			configPtr.unsaved = true
+			unlocked = append(unlocked, a)
+		}
+	}
+	return unlocked
+}
+
+// announceAchievements prints a notification for each newly unlocked achievement.
+func announceAchievements(unlocked []achievement) {
+	for _, a := range unlocked {
+		color.New(color.FgHiYellow, color.Bold).Printf("🏆 Achievement unlocked: %v", a.Name)
+		color.New(color.FgHiBlack).Printf(" (%v)\n", a.Description)
+	}
+}
+
+// formatPlayTime formats a play time in hours and minutes, e.g. "3h 05m".
+func formatPlayTime(d time.Duration) string {
+	minutes := int(d / time.Minute)
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh %02dm", minutes/60, minutes%60)
+}
+
+// trainer shows the trainer profile; trainer name <name> sets the trainer's name.
+func trainer(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	if len(args) > 0 && args[0] == "name" {
+		if len(args) < 2 {
+			color.New(color.FgHiRed, color.Bold).Println("Usage: trainer name <name>")
+			return nil
+		}
+		words := []string{}
+		for _, word := range args[1:] {
+			words = append(words, originalWord(configPtr.typed, word))
+		}
+		configPtr.Trainer.Name = strings.Join(words, " ")
+		configPtr.unsaved = true
+		color.New(color.FgHiGreen, color.Bold).Printf("Welcome, Trainer %v!\n", configPtr.Trainer.Name)
+		return nil
+	}
+	if len(args) > 0 {
+		color.New(color.FgHiRed, color.Bold).Println("Usage: trainer [name <name>]")
+		return nil
+	}
+
+	profile := configPtr.Trainer
+	label := color.New(color.FgCyan, color.Bold)
+	name := profile.Name
+	if name == "" {
+		name = "(no name yet; set one with trainer name <name>)"
+	}
+	label.Print("Trainer:      ")
+	color.New(color.FgHiWhite, color.Bold).Println(name)
+	if !profile.Started.IsZero() {
+		label.Print("Started:      ")
+		fmt.Println(profile.Started.Format("2006-01-02"))
+	}
+	label.Print("Play time:    ")
+	fmt.Println(formatPlayTime(profile.PlayTime))
+	label.Print("Pokéballs:    ")
+	fmt.Printf("%d thrown, %d caught, %d missed", profile.Throws, profile.Catches, profile.Misses)
+	if profile.Throws > 0 {
+		fmt.Printf(" (%v success)", percent(profile.Catches, profile.Throws))
+	}
+	fmt.Println()
+	label.Print("Areas:        ")
+	fmt.Printf("%d explored\n", len(profile.Explored))
+	label.Print("Pokédex:      ")
+	fmt.Printf("%d seen, %d caught, %d owned\n", len(configPtr.Seen), len(pokedex), len(configPtr.allOwned()))
+	label.Print("Achievements: ")
+	fmt.Printf("%d of %d unlocked\n", len(profile.Achievements), len(achievements))
+	return nil
+}
+
+// showAchievements lists every achievement with its unlock date, or the progress toward it.
+func showAchievements(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	rows := [][]string{}
+	for _, a := range achievements {
+		status := ""
+		if at, done := configPtr.Trainer.Achievements[a.ID]; done {
+			status = "✓ " + at.Format("2006-01-02")
+		} else {
+			current, goal := a.progress(configPtr, pokedex)
+			status = fmt.Sprintf("%d/%d", min(current, goal), goal)
+		}
+		rows = append(rows, []string{a.Name, a.Description, status})
+	}
+	color.New(color.FgCyan, color.Bold).Printf("Achievements (%d of %d unlocked):\n", len(configPtr.Trainer.Achievements), len(achievements))
+	printTable([]string{"Achievement", "Goal", "Status"}, rows, func(row, col int) *color.Color {
+		if _, done := configPtr.Trainer.Achievements[achievements[row].ID]; done {
+			return color.New(color.FgHiGreen, color.Bold)
+		}
+		return color.New(color.FgHiBlack)
+	})
+	return nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTickPlayTime(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	configPtr := &config{}
+
+	configPtr.tickPlayTime(start)
+	if !configPtr.Trainer.Started.Equal(start) || configPtr.Trainer.PlayTime != 0 {
+		t.Errorf("First command: Expected the profile to start with no play time, Got %+v", configPtr.Trainer)
+	}
+	configPtr.tickPlayTime(start.Add(2 * time.Minute))
+	// An hour away from the keyboard counts as maxIdle only.
+	configPtr.tickPlayTime(start.Add(62 * time.Minute))
+	if expected := 2*time.Minute + maxIdle; configPtr.Trainer.PlayTime != expected {
+		t.Errorf("Expected %v, Got %v", expected, configPtr.Trainer.PlayTime)
+	}
+	if !configPtr.Trainer.Started.Equal(start) {
+		t.Errorf("Expected the start date to stay %v, Got %v", start, configPtr.Trainer.Started)
+	}
+	// Play time alone doesn't rewrite the save; it waits for the next one.
+	if configPtr.unsaved || !configPtr.playTimeDue {
+		t.Errorf("Expected the play time to wait for the next save, Got unsaved %v, due %v", configPtr.unsaved, configPtr.playTimeDue)
+	}
+	if err := writeSave(filepath.Join(t.TempDir(), "save.json"), configPtr, map[string]pokemonDetails{}); err != nil {
+		t.Fatalf("could not save: %v", err)
+	}
+	if configPtr.playTimeDue {
+		t.Errorf("Expected the save to include the play time")
+	}
+}
+
+func TestRecordThrow(t *testing.T) {
+	configPtr := &config{}
+	configPtr.recordThrow(false)
+	configPtr.recordThrow(true)
+	configPtr.recordThrow(true)
+
+	profile := configPtr.Trainer
+	if profile.Throws != 3 || profile.Catches != 2 || profile.Misses != 1 {
+		t.Errorf("Expected 3 throws, 2 catches and 1 miss, Got %+v", profile)
+	}
+}
+
+func TestCheckAchievements(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	configPtr := &config{}
+	pokedex := map[string]pokemonDetails{"bulbasaur": {}, "charmander": {}}
+
+	unlocked := checkAchievements(configPtr, pokedex, now)
+	if len(unlocked) != 1 || unlocked[0].ID != "first-catch" {
+		t.Errorf("Expected only first-catch to unlock, Got %v", unlocked)
+	}
+
+	pokedex["squirtle"] = pokemonDetails{}
+	unlocked = checkAchievements(configPtr, pokedex, now)
+	if len(unlocked) != 1 || unlocked[0].ID != "kanto-starters" {
+		t.Errorf("Expected only kanto-starters to unlock, Got %v", unlocked)
+	}
+
+	// Unlocked achievements stay unlocked and are announced once.
+	delete(pokedex, "squirtle")
+	if unlocked = checkAchievements(configPtr, pokedex, now); len(unlocked) != 0 {
+		t.Errorf("Expected nothing new, Got %v", unlocked)
+	}
+	if _, done := configPtr.Trainer.Achievements["kanto-starters"]; !done {
+		t.Errorf("Expected kanto-starters to stay unlocked")
+	}
+}
+
+// TestLegendaryAchievement checks that a legendary caught before achievements existed counts.
+func TestLegendaryAchievement(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	configPtr := &config{Trainer: trainerProfile{Achievements: map[string]time.Time{"first-catch": now}}}
+	mewtwo := pokemonDetails{ID: 150}
+	mewtwo.Species.URL = "https://pokeapi.co/api/v2/pokemon-species/150/"
+
+	unlocked := checkAchievements(configPtr, map[string]pokemonDetails{"mewtwo": mewtwo}, now)
+	if len(unlocked) != 1 || unlocked[0].ID != "legendary" {
+		t.Errorf("Expected only legendary to unlock, Got %v", unlocked)
+	}
+}
+
+func TestFormatPlayTime(t *testing.T) {
+	cases := []struct {
+		input    time.Duration
+		expected string
+	}{
+		{input: 30 * time.Second, expected: "0m"},
+		{input: 42 * time.Minute, expected: "42m"},
+		{input: 3*time.Hour + 5*time.Minute, expected: "3h 05m"},
+	}
+	for _, c := range cases {
+		if actual := formatPlayTime(c.input); actual != c.expected {
+			t.Errorf("formatPlayTime(%v) Expected %v, Got %v", c.input, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,623 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
+	"github.com/fatih/color"
+)
+
+const (
+	minTUIWidth   = 60 // Below this the panes don't fit
+	minTUIHeight  = 16
+	gridCellWidth = 19 // Width of one Pokédex grid cell, e.g. "#0025 pikachu"
+)
+
+// tuiPane is a pane that can have the keyboard focus.
+type tuiPane int
+
+const (
+	locationsPane tuiPane = iota
+	encountersPane
+	pokedexPane
+	paneCount
+)
+
+// tuiHelp is shown in the detail pane on start and with ?.
+var tuiHelp = []string{
+	"Keys:",
+	"  Tab / Shift+Tab   switch pane",
+	"  ↑ ↓ ← → / hjkl    move the selection",
+	"  Enter             explore an area, catch an encounter, inspect a Pokémon",
+	"  s                 show the sprite of the selected Pokémon",
+	"  PgUp / PgDn       scroll this pane",
+	"  :                 run any command, e.g. :battle or :version red",
+	"  ?                 this help",
+	"  q / Ctrl+C        quit",
+}
+
+// listState is the items, selection and scroll position of a list pane.
+type listState struct {
+	items    []string
+	selected int
+	scroll   int // First visible item
+}
+
+// move changes the selection by delta, staying within the list.
+func (l *listState) move(delta int) {
+	l.selected = max(0, min(l.selected+delta, len(l.items)-1))
+}
+
+// current returns the selected item.
+func (l *listState) current() (string, bool) {
+	if l.selected < 0 || l.selected >= len(l.items) {
+		return "", false
+	}
+	return l.items[l.selected], true
+}
+
+// reveal scrolls so that item index is among the visible rows.
+func (l *listState) reveal(index, visible int) {
+	if index < l.scroll {
+		l.scroll = index
+	}
+	if visible > 0 && index >= l.scroll+visible {
+		l.scroll = index - visible + 1
+	}
+	l.scroll = max(0, l.scroll)
+}
+
+// tui is the state of the full-screen mode.
+type tui struct {
+	configPtr *config
+	cachePtr  *internal.Cache
+	pokedex   map[string]pokemonDetails
+	savePath  string
+
+	width, height int
+	focus         tuiPane
+	quit          bool
+
+	locations        listState
+	locationsTotal   int    // How many areas there are, to know when all are loaded
+	locationsVersion string // The game the location list was loaded for
+	encounters       listState
+	encountersArea   string // The area the encounter list belongs to
+	dex              listState
+	gridCols         int // Columns of the Pokédex grid at the last render
+
+	detailTitle  string
+	detail       []string
+	detailScroll int
+	detailRows   int // Visible rows of the detail pane at the last render
+
+	status      string
+	commandMode bool
+	command     []rune
+}
+
+// captureOutput runs fn and returns everything it printed instead of printing it,
+// so the command handlers can draw into a pane.
+func captureOutput(fn func()) string {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return err.Error()
+	}
+	stdout, colorOutput := os.Stdout, color.Output
+	os.Stdout, color.Output = writer, writer
+
+	// Read while fn runs so a long output can't fill the pipe and block it.
+	done := make(chan string)
+	go func() {
+		var b bytes.Buffer
+		io.Copy(&b, reader)
+		done <- b.String()
+	}()
+	fn()
+
+	writer.Close()
+	os.Stdout, color.Output = stdout, colorOutput
+	output := <-done
+	reader.Close()
+	return output
+}
+
+// fitLine cuts or pads text to exactly width columns. ANSI escape codes are kept
+// but take no room, and the colors are reset at the end of the line.
+func fitLine(text string, width int) string {
+	var b strings.Builder
+	visible := 0
+	for i := 0; i < len(text); {
+		if text[i] == '\x1b' {
+			// Copy a "CSI ... final byte" escape sequence whole.
+			end := i + 1
+			if end < len(text) && text[end] == '[' {
+				end++
+				for end < len(text) && (text[end] < 0x40 || text[end] > 0x7e) {
+					end++
+				}
+			}
+			end = min(end+1, len(text))
+			b.WriteString(text[i:end])
+			i = end
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+		if r == '\r' {
+			continue
+		}
+		if visible == width {
+			break
+		}
+		if r == '\t' {
+			r = ' '
+		}
+		b.WriteRune(r)
+		visible++
+	}
+	b.WriteString(strings.Repeat(" ", width-visible))
+	b.WriteString("\x1b[0m")
+	return b.String()
+}
+
+// parseKey names the key in one read from the terminal, e.g. "up", "enter" or "q".
+// Unknown escape sequences give "".
+func parseKey(input []byte) string {
+	switch text := string(input); text {
+	case "\x1b[A", "\x1bOA":
+		return "up"
+	case "\x1b[B", "\x1bOB":
+		return "down"
+	case "\x1b[C", "\x1bOC":
+		return "right"
+	case "\x1b[D", "\x1bOD":
+		return "left"
+	case "\x1b[5~":
+		return "pgup"
+	case "\x1b[6~":
+		return "pgdown"
+	case "\x1b[Z":
+		return "backtab"
+	case "\x1b":
+		return "esc"
+	case "\r", "\n":
+		return "enter"
+	case "\t":
+		return "tab"
+	case "\x7f", "\b":
+		return "backspace"
+	case "\x03":
+		return "ctrl-c"
+	default:
+		if strings.HasPrefix(text, "\x1b") {
+			return ""
+		}
+		return text
+	}
+}
+
+// paneLines draws a bordered pane of width x height around content,
+// which must already be cut to the pane's inner size.
+func paneLines(title string, content []string, width, height int, focused bool) []string {
+	border := color.New(color.FgHiBlack)
+	if focused {
+		border = color.New(color.FgCyan, color.Bold)
+	}
+	inner := width - 2
+	top := "─ " + title + " " + strings.Repeat("─", max(0, inner-utf8.RuneCountInString(title)-3))
+	lines := []string{border.Sprint("┌") + border.Sprint(fitLine(top, inner)) + border.Sprint("┐")}
+	for row := 0; row < height-2; row++ {
+		text := ""
+		if row < len(content) {
+			text = content[row]
+		}
+		lines = append(lines, border.Sprint("│")+fitLine(text, inner)+border.Sprint("│"))
+	}
+	lines = append(lines, border.Sprint("└"+strings.Repeat("─", inner)+"┘"))
+	return lines
+}
+
+// listLines returns the visible rows of a list pane, with the selection highlighted.
+func listLines(list *listState, rows, width int, focused bool, label func(string) string) []string {
+	list.reveal(list.selected, rows)
+	lines := []string{}
+	for i := list.scroll; i < len(list.items) && len(lines) < rows; i++ {
+		// The marker shows the selection even when colors are off.
+		marker := " "
+		if i == list.selected {
+			marker = "▸"
+		}
+		text := fitLine(marker+label(list.items[i]), width)
+		if i == list.selected {
+			highlight := color.New(color.Bold)
+			if focused {
+				highlight = color.New(color.ReverseVideo)
+			}
+			text = highlight.Sprint(text)
+		}
+		lines = append(lines, text)
+	}
+	return lines
+}
+
+// flash shows a status message right away, before a slow request.
+func (t *tui) flash(message string) {
+	t.status = message
+	os.Stdout.WriteString(t.render())
+}
+
+// loadMoreLocations appends the next page of location areas to the location list.
+func (t *tui) loadMoreLocations() {
+	if t.locationsVersion == t.configPtr.Version && len(t.locations.items) > 0 && len(t.locations.items) >= t.locationsTotal {
+		return
+	}
+	t.flash("Loading locations...")
+	names, total, err := areaPage(t.configPtr, t.cachePtr, len(t.locations.items), t.configPtr.pageLimit())
+	if err != nil {
+		t.status = fmt.Sprintf("Loading locations failed: %v", err)
+		return
+	}
+	t.locations.items = append(t.locations.items, names...)
+	t.locationsTotal = total
+	t.status = fmt.Sprintf("%d of %d areas loaded", len(t.locations.items), total)
+}
+
+// refresh brings the panes up to date with the game after a command.
+func (t *tui) refresh() {
+	// A different game has different areas.
+	if t.locationsVersion != t.configPtr.Version || len(t.locations.items) == 0 {
+		t.locations = listState{}
+		t.locationsVersion = t.configPtr.Version
+		t.loadMoreLocations()
+	}
+
+	if area := t.configPtr.CurrentArea; area != t.encountersArea {
+		t.encounters = listState{}
+		t.encountersArea = area
+		if area != "" {
+			t.flash("Loading encounters...")
+			areaDetails, err := fetchLocationArea(t.cachePtr, area)
+			if err != nil {
+				t.status = fmt.Sprintf("Loading %v failed: %v", area, err)
+			}
+			for i, encounter := range areaDetails.PokemonEncounters {
+				if inVersion(encounterVersionNames(areaDetails, i), t.configPtr.Version) {
+					t.encounters.items = append(t.encounters.items, encounter.Pokemon.Name)
+				}
+			}
+		}
+	}
+
+	t.dex.items = []string{}
+	for _, row := range dexRows(t.configPtr, t.pokedex) {
+		t.dex.items = append(t.dex.items, row.Name)
+	}
+	t.dex.move(0)
+}
+
+// runCommand runs a command line like the REPL would and shows its output in the detail pane.
+func (t *tui) runCommand(line string) {
+	words := cleanInput(line)
+	if t.configPtr.Evolution == nil && len(words) > 0 && words[0] == "exit" {
+		// exit would end the program without giving the terminal back.
+		t.quit = true
+		return
+	}
+
+	t.flash("Running " + line + "...")
+	t.status = ""
+	output := captureOutput(func() {
+		runInput(t.configPtr, t.cachePtr, t.pokedex, t.savePath, line)
+	})
+	t.detailTitle = line
+	t.detail = strings.Split(strings.TrimRight(output, "\n"), "\n")
+	t.detailScroll = 0
+	t.refresh()
+
+	if t.configPtr.Evolution != nil {
+		t.commandMode, t.command = true, nil
+		t.status = "Answer yes or no."
+	}
+}
+
+// activate does what Enter means in the focused pane.
+func (t *tui) activate() {
+	switch t.focus {
+	case locationsPane:
+		if area, ok := t.locations.current(); ok {
+			t.runCommand("explore " + area)
+			t.focus = encountersPane
+		}
+	case encountersPane:
+		if name, ok := t.encounters.current(); ok {
+			t.runCommand("catch " + name)
+		}
+	case pokedexPane:
+		if name, ok := t.dex.current(); ok {
+			t.runCommand("inspect " + name)
+		}
+	}
+}
+
+// moveSelection moves the selection of the focused pane; dx only matters in the Pokédex grid.
+func (t *tui) moveSelection(dx, dy int) {
+	switch t.focus {
+	case locationsPane:
+		t.locations.move(dy)
+		if t.locations.selected >= len(t.locations.items)-1 {
+			t.loadMoreLocations()
+		}
+	case encountersPane:
+		t.encounters.move(dy)
+	case pokedexPane:
+		t.dex.move(dx + dy*max(1, t.gridCols))
+	}
+}
+
+// handleKey reacts to one key press.
+func (t *tui) handleKey(key string) {
+	if t.commandMode {
+		switch key {
+		case "enter":
+			t.commandMode = false
+			line := string(t.command)
+			t.command = nil
+			t.runCommand(line)
+		case "esc":
+			if t.configPtr.Evolution == nil {
+				t.commandMode, t.command = false, nil
+			}
+		case "backspace":
+			if len(t.command) > 0 {
+				t.command = t.command[:len(t.command)-1]
+			}
+		case "ctrl-c":
+			t.quit = true
+		default:
+			if key != "" && !strings.ContainsFunc(key, isControl) {
+				t.command = append(t.command, []rune(key)...)
+			}
+		}
+		return
+	}
+
+	switch key {
+	case "q", "ctrl-c":
+		t.quit = true
+	case "tab":
+		t.focus = (t.focus + 1) % paneCount
+	case "backtab":
+		t.focus = (t.focus + paneCount - 1) % paneCount
+	case "up", "k":
+		t.moveSelection(0, -1)
+	case "down", "j":
+		t.moveSelection(0, 1)
+	case "left", "h":
+		t.moveSelection(-1, 0)
+	case "right", "l":
+		t.moveSelection(1, 0)
+	case "pgup":
+		t.detailScroll = max(0, t.detailScroll-max(1, t.detailRows-1))
+	case "pgdown":
+		t.detailScroll = max(0, min(t.detailScroll+max(1, t.detailRows-1), len(t.detail)-t.detailRows))
+	case "enter":
+		t.activate()
+	case "s":
+		name, ok := "", false
+		if t.focus == encountersPane {
+			name, ok = t.encounters.current()
+		} else if t.focus == pokedexPane {
+			name, ok = t.dex.current()
+		}
+		if ok {
+			t.runCommand("sprite " + name)
+		}
+	case ":", "/":
+		t.commandMode, t.command = true, nil
+	case "?":
+		t.detailTitle, t.detail, t.detailScroll = "Help", tuiHelp, 0
+	}
+}
+
+// isControl reports whether r is a control character.
+func isControl(r rune) bool {
+	return r < 0x20 || r == 0x7f
+}
+
+// render draws the whole screen.
+func (t *tui) render() string {
+	var b strings.Builder
+	b.WriteString("\x1b[H")
+	if t.width < minTUIWidth || t.height < minTUIHeight {
+		b.WriteString("\x1b[2J")
+		b.WriteString(fmt.Sprintf("The terminal is too small (%dx%d); full-screen mode needs %dx%d. Press q to quit.", t.width, t.height, minTUIWidth, minTUIHeight))
+		return b.String()
+	}
+
+	// Title bar
+	area := t.configPtr.CurrentArea
+	if area == "" {
+		area = "nowhere yet"
+	}
+	game := t.configPtr.Version
+	if game == "" {
+		game = "all games"
+	}
+	title := fmt.Sprintf(" pokedexCLI │ %v │ %v │ party %d/%d │ caught %d species", area, game, len(t.configPtr.Party), maxPartySize, len(t.pokedex))
+	if t.configPtr.Battle != nil {
+		title += " │ IN BATTLE"
+	}
+	screen := []string{color.New(color.ReverseVideo, color.Bold).Sprint(fitLine(title, t.width))}
+
+	// Panes: locations and encounters over the Pokédex grid on the left, details on the right
+	bodyHeight := t.height - 3
+	leftWidth := t.width * 55 / 100
+	topHeight := bodyHeight / 2
+	locationsWidth := leftWidth / 2
+	encountersWidth := leftWidth - locationsWidth
+	detailWidth := t.width - leftWidth
+
+	locationsTitle := fmt.Sprintf("Locations (%d/%d)", len(t.locations.items), t.locationsTotal)
+	locations := paneLines(locationsTitle,
+		listLines(&t.locations, topHeight-2, locationsWidth-2, t.focus == locationsPane, func(s string) string { return s }),
+		locationsWidth, topHeight, t.focus == locationsPane)
+
+	encountersTitle := "Encounters"
+	if t.encountersArea != "" {
+		encountersTitle = "Wild in " + t.encountersArea
+	}
+	encounters := paneLines(encountersTitle,
+		listLines(&t.encounters, topHeight-2, encountersWidth-2, t.focus == encountersPane, func(name string) string {
+			if _, caught := t.pokedex[name]; caught {
+				return name + " ●"
+			}
+			return name
+		}),
+		encountersWidth, topHeight, t.focus == encountersPane)
+
+	dexHeight := bodyHeight - topHeight
+	dex := paneLines(fmt.Sprintf("Pokédex (%d)", len(t.dex.items)), t.gridLines(dexHeight-2, leftWidth-2), leftWidth, dexHeight, t.focus == pokedexPane)
+
+	t.detailRows = bodyHeight - 2
+	detailTitle := t.detailTitle
+	if len(t.detail) > t.detailRows {
+		detailTitle += fmt.Sprintf(" [%d-%d of %d]", t.detailScroll+1, min(t.detailScroll+t.detailRows, len(t.detail)), len(t.detail))
+	}
+	visible := []string{}
+	if t.detailScroll < len(t.detail) {
+		visible = t.detail[t.detailScroll:]
+	}
+	detail := paneLines(detailTitle, visible, detailWidth, bodyHeight, false)
+
+	for row := 0; row < bodyHeight; row++ {
+		left := ""
+		if row < topHeight {
+			left = locations[row] + encounters[row]
+		} else {
+			left = dex[row-topHeight]
+		}
+		screen = append(screen, left+detail[row])
+	}
+
+	// Status and command bar
+	screen = append(screen, color.New(color.FgHiYellow).Sprint(fitLine(" "+t.status, t.width)))
+	if t.commandMode {
+		screen = append(screen, fitLine(color.New(color.FgCyan, color.Bold).Sprint(" : ")+string(t.command)+"█", t.width))
+	} else {
+		hints := " Tab pane • ↑↓←→ move • Enter select • s sprite • PgUp/PgDn scroll • : command • ? help • q quit"
+		screen = append(screen, color.New(color.FgHiBlack).Sprint(fitLine(hints, t.width)))
+	}
+
+	for i, line := range screen {
+		b.WriteString(fmt.Sprintf("\x1b[%d;1H%s", i+1, line))
+	}
+	return b.String()
+}
+
+// gridLines lays the caught species out in columns, in national dex order.
+func (t *tui) gridLines(rows, width int) []string {
+	t.gridCols = max(1, width/gridCellWidth)
+	t.dex.reveal(t.dex.selected/t.gridCols, rows)
+
+	lines := []string{}
+	for row := t.dex.scroll; len(lines) < rows; row++ {
+		start := row * t.gridCols
+		if start >= len(t.dex.items) {
+			break
+		}
+		var b strings.Builder
+		for i := start; i < start+t.gridCols && i < len(t.dex.items); i++ {
+			name := t.dex.items[i]
+			marker := " "
+			if i == t.dex.selected {
+				marker = "▸"
+			}
+			cell := fitLine(fmt.Sprintf("%v#%04d %v", marker, speciesNumber(t.pokedex[name]), name), gridCellWidth)
+			if i == t.dex.selected {
+				highlight := color.New(color.Bold)
+				if t.focus == pokedexPane {
+					highlight = color.New(color.ReverseVideo)
+				}
+				cell = highlight.Sprint(cell)
+			}
+			b.WriteString(cell)
+		}
+		lines = append(lines, b.String())
+	}
+	return lines
+}
+
+// runTUI runs the full-screen mode until the user quits. It uses the same
+// command handlers, game state and save file as the REPL.
+func runTUI(savePath string, configPtr *config, pokedex map[string]pokemonDetails) error {
+	restore, err := makeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	// Switch to the alternate screen and hide the cursor; undo both on the way out.
+	os.Stdout.WriteString("\x1b[?1049h\x1b[?25l")
+	defer os.Stdout.WriteString("\x1b[?25h\x1b[?1049l")
+
+	t := &tui{
+		configPtr: configPtr,
+		cachePtr:  internal.NewCache(30 * time.Second),
+		pokedex:   pokedex,
+		savePath:  savePath,
+		width:     80,
+		height:    24,
+	}
+	t.detailTitle, t.detail = "Help", tuiHelp
+	t.refresh()
+
+	defer savePlayTime(savePath, configPtr, pokedex)
+
+	// Keys are read on their own goroutine so that a resize can redraw the screen
+	// while waiting for one.
+	keys, readErr := make(chan []byte), make(chan error, 1)
+	go func() {
+		for {
+			input := make([]byte, 64)
+			n, err := os.Stdin.Read(input)
+			if err != nil {
+				readErr <- err
+				return
+			}
+			keys <- input[:n]
+		}
+	}()
+	resized := make(chan os.Signal, 1)
+	if signals := resizeSignals(); len(signals) > 0 {
+		signal.Notify(resized, signals...)
+		defer signal.Stop(resized)
+	}
+
+	for !t.quit {
+		if width, height, err := terminalSize(int(os.Stdout.Fd())); err == nil {
+			t.width, t.height = width, height
+		}
+		os.Stdout.WriteString(t.render())
+
+		select {
+		case input := <-keys:
+			t.handleKey(parseKey(input))
+		case err := <-readErr:
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		case <-resized:
+		}
+	}
+	return nil
+}
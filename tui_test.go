@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestFitLine(t *testing.T) {
+	cases := []struct {
+		input    string
+		width    int
+		expected string
+	}{
+		{input: "abc", width: 5, expected: "abc  \x1b[0m"},
+		{input: "abcdef", width: 4, expected: "abcd\x1b[0m"},
+		{input: "\x1b[31mred\x1b[0m", width: 4, expected: "\x1b[31mred\x1b[0m \x1b[0m"},
+		{input: "Pokédex", width: 7, expected: "Pokédex\x1b[0m"},
+		{input: "a\tb\r", width: 3, expected: "a b\x1b[0m"},
+	}
+
+	for _, c := range cases {
+		actual := fitLine(c.input, c.width)
+		if actual != c.expected {
+			t.Errorf("fitLine(%q, %d) Expected %q, Got %q", c.input, c.width, c.expected, actual)
+		}
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "\x1b[A", expected: "up"},
+		{input: "\x1bOB", expected: "down"},
+		{input: "\x1b[6~", expected: "pgdown"},
+		{input: "\x1b[Z", expected: "backtab"},
+		{input: "\r", expected: "enter"},
+		{input: "\t", expected: "tab"},
+		{input: "\x7f", expected: "backspace"},
+		{input: "q", expected: "q"},
+		{input: "\x1b[15~", expected: ""},
+	}
+
+	for _, c := range cases {
+		actual := parseKey([]byte(c.input))
+		if actual != c.expected {
+			t.Errorf("parseKey(%q) Expected %q, Got %q", c.input, c.expected, actual)
+		}
+	}
+}
+
+func TestListState(t *testing.T) {
+	list := &listState{items: []string{"a", "b", "c", "d", "e"}}
+
+	list.move(-1)
+	if list.selected != 0 {
+		t.Errorf("Moving up from the top: Expected 0, Got %v", list.selected)
+	}
+	list.move(10)
+	if item, _ := list.current(); item != "e" {
+		t.Errorf("Moving past the end: Expected e, Got %v", item)
+	}
+
+	list.reveal(list.selected, 2)
+	if list.scroll != 3 {
+		t.Errorf("Revealing the last item: Expected scroll 3, Got %v", list.scroll)
+	}
+	list.reveal(1, 2)
+	if list.scroll != 1 {
+		t.Errorf("Revealing an item above: Expected scroll 1, Got %v", list.scroll)
+	}
+
+	empty := &listState{}
+	if _, ok := empty.current(); ok {
+		t.Errorf("Empty list: Expected no current item")
+	}
+}
+
+func TestCaptureOutput(t *testing.T) {
+	stdout := os.Stdout
+	output := captureOutput(func() {
+		fmt.Println("caught pikachu")
+	})
+	if output != "caught pikachu\n" {
+		t.Errorf("Expected %q, Got %q", "caught pikachu\n", output)
+	}
+	if os.Stdout != stdout {
+		t.Errorf("Expected os.Stdout to be restored")
+	}
+}
+
+// TestGridLines checks that the grid numbers forms by their species, like the pokedex listing.
+func TestGridLines(t *testing.T) {
+	vulpix := pokemonDetails{ID: 10103, Name: "vulpix-alola"}
+	vulpix.Species.URL = "https://pokeapi.co/api/v2/pokemon-species/37/"
+	ui := &tui{pokedex: map[string]pokemonDetails{"vulpix-alola": vulpix}}
+	ui.dex.items = []string{"vulpix-alola"}
+	ui.dex.selected = -1
+
+	lines := ui.gridLines(1, 80)
+	if len(lines) != 1 || !strings.Contains(lines[0], "#0037 vulpix-alola") {
+		t.Errorf("Expected #0037 vulpix-alola, Got %q", lines)
+	}
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Marcus-Gustafsson/pokedexCLI/internal"
+	"github.com/fatih/color"
+)
+
+// namedList is a page of a PokeAPI list endpoint, e.g. /version or /region.
+type namedList struct {
+	Count   int     `json:"count"`
+	Next    *string `json:"next"`
+	Results []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"results"`
+}
+
+// versionDetails holds the data from the PokeAPI version endpoint.
+type versionDetails struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"` // e.g. "red"
+	VersionGroup struct {
+		Name string `json:"name"` // e.g. "red-blue"
+		URL  string `json:"url"`
+	} `json:"version_group"`
+}
+
+// versionGroupDetails holds the data from the PokeAPI version-group endpoint.
+type versionGroupDetails struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	Generation struct {
+		Name string `json:"name"` // e.g. "generation-i"
+		URL  string `json:"url"`
+	} `json:"generation"`
+	Regions []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"regions"`
+}
+
+// regionDetails holds the data from the PokeAPI region endpoint.
+type regionDetails struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Locations []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"locations"`
+	MainGeneration struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"main_generation"`
+	VersionGroups []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"version_groups"`
+}
+
+// locationDetails holds the data from the PokeAPI location endpoint.
+type locationDetails struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Region struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"region"`
+	Areas []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"areas"`
+}
+
+// fetchNamedList fetches every name of a PokeAPI list endpoint in one request.
+func fetchNamedList(cachePtr *internal.Cache, endpoint string) (namedList, error) {
+	var list namedList
+	url := fmt.Sprintf("https://pokeapi.co/api/v2/%v/?offset=0&limit=100000", endpoint)
+	val, err := fetchData(cachePtr, url)
+	if err != nil {
+		return list, err
+	}
+	err = json.Unmarshal(val, &list)
+	return list, err
+}
+
+// fetchResource fetches /<endpoint>/<name>/ and unmarshals it into target.
+func fetchResource(cachePtr *internal.Cache, endpoint, name string, target any) error {
+	url := fmt.Sprintf("https://pokeapi.co/api/v2/%v/%v/", endpoint, name)
+	val, err := fetchData(cachePtr, url)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(val, target)
+}
+
+// versionAreas returns the location areas of the regions in the selected game.
+// Building the list takes a request per location, so it is kept for the session.
+func versionAreas(configPtr *config, cachePtr *internal.Cache) ([]string, error) {
+	if configPtr.versionAreas != nil {
+		return configPtr.versionAreas, nil
+	}
+
+	var group versionGroupDetails
+	if err := fetchResource(cachePtr, "version-group", configPtr.VersionGroup, &group); err != nil {
+		return nil, err
+	}
+
+	color.New(color.FgHiBlack).Printf("Charting the areas of pokemon %v...\n", configPtr.Version)
+	areas := []string{}
+	for _, r := range group.Regions {
+		var region regionDetails
+		if err := fetchResource(cachePtr, "region", r.Name, &region); err != nil {
+			return nil, err
+		}
+		for _, l := range region.Locations {
+			var location locationDetails
+			if err := fetchResource(cachePtr, "location", l.Name, &location); err != nil {
+				return nil, err
+			}
+			for _, area := range location.Areas {
+				areas = append(areas, area.Name)
+			}
+		}
+	}
+	configPtr.versionAreas = areas
+	return areas, nil
+}
+
+// inVersion reports whether a Pokémon can be encountered in an area in the given version.
+// With no version selected every encounter counts.
+func inVersion(versionDetails []string, version string) bool {
+	if version == "" {
+		return true
+	}
+	for _, name := range versionDetails {
+		if name == version {
+			return true
+		}
+	}
+	return false
+}
+
+// encounterVersionNames lists the versions an area encounter appears in.
+func encounterVersionNames(areaDetails locationAreaDetails, index int) []string {
+	names := []string{}
+	for _, versionDetail := range areaDetails.PokemonEncounters[index].VersionDetails {
+		names = append(names, versionDetail.Version.Name)
+	}
+	return names
+}
+
+// flavorText returns the English Pokédex entry of a species for a version,
+// or the newest English entry when no version is selected or it has none.
+func flavorText(species pokemonSpecies, version string) (string, string) {
+	text, from := "", ""
+	for _, entry := range species.FlavorTextEntries {
+		if entry.Language.Name != "en" {
+			continue
+		}
+		// Entries are oldest first, so the last English one is the newest.
+		text, from = entry.FlavorText, entry.Version.Name
+		if version != "" && entry.Version.Name == version {
+			break
+		}
+	}
+	// Entries contain hard line breaks and form feeds from the game text boxes.
+	return strings.Join(strings.Fields(text), " "), from
+}
+
+// knownVersion reports whether name is one of the PokeAPI's versions. If it isn't,
+// it tells the user which games there are.
+func knownVersion(cachePtr *internal.Cache, name string) (bool, error) {
+	list, err := fetchNamedList(cachePtr, "version")
+	if err != nil {
+		return false, err
+	}
+	known := []string{}
+	for _, result := range list.Results {
+		if result.Name == name {
+			return true, nil
+		}
+		known = append(known, result.Name)
+	}
+	color.New(color.FgHiRed, color.Bold).Printf("Unknown game %v. Choose one of:\n", name)
+	color.New(color.FgHiBlack).Printf("  %v\n", strings.Join(known, ", "))
+	return false, nil
+}
+
+// version selects the game being played, e.g. version red. It affects encounters,
+// learnsets, sprites, Pokédex entries and the location list. Without arguments
+// it shows the current game; version none goes back to all games.
+func version(configPtr *config, cachePtr *internal.Cache, args []string, pokedex map[string]pokemonDetails) error {
+	if len(args) == 0 {
+		if configPtr.Version == "" {
+			color.New(color.FgCyan, color.Bold).Println("No game selected; data from every game is used. Pick one with version <game>.")
+		} else {
+			color.New(color.FgCyan, color.Bold).Printf("You are playing pokemon %v (%v).\n", configPtr.Version, configPtr.VersionGroup)
+		}
+		return nil
+	}
+
+	if args[0] == "none" || args[0] == "all" {
+		configPtr.Version, configPtr.VersionGroup = "", ""
+		configPtr.versionAreas, configPtr.mapShown, configPtr.mapOffset = nil, false, 0
+		configPtr.unsaved = true
+		color.New(color.FgCyan, color.Bold).Println("Game cleared; data from every game is used again.")
+		return nil
+	}
+
+	if known, err := knownVersion(cachePtr, args[0]); err != nil || !known {
+		return err
+	}
+
+	var details versionDetails
+	if err := fetchResource(cachePtr, "version", args[0], &details); err != nil {
+		return err
+	}
+	configPtr.Version = details.Name
+	configPtr.VersionGroup = details.VersionGroup.Name
+	configPtr.versionAreas, configPtr.mapShown, configPtr.mapOffset = nil, false, 0 // map starts over in the new game's regions
+	configPtr.unsaved = true
+
+	color.New(color.FgHiGreen, color.Bold).Printf("Now playing pokemon %v (%v)!\n", details.Name, details.VersionGroup.Name)
+	return nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestCanEncounterInVersion checks that encounters are limited to the selected game.
+func TestCanEncounterInVersion(t *testing.T) {
+	var areaDetails locationAreaDetails
+	if err := json.Unmarshal([]byte(testArea), &areaDetails); err != nil {
+		t.Fatalf("could not parse test area: %v", err)
+	}
+
+	cases := []struct {
+		pokemon  string
+		version  string
+		expected bool
+	}{
+		{pokemon: "pidgey", version: "", expected: true},
+		{pokemon: "pidgey", version: "red", expected: true},
+		{pokemon: "pidgey", version: "blue", expected: false},
+		{pokemon: "magikarp", version: "blue", expected: true},
+		{pokemon: "mew", version: "", expected: false},
+	}
+	for _, c := range cases {
+		if actual := canEncounter(areaDetails, c.pokemon, c.version); actual != c.expected {
+			t.Errorf("%v in %q: Expected %v, Got %v", c.pokemon, c.version, c.expected, actual)
+		}
+	}
+}
+
+// TestFlavorText checks entry selection by version and language, and whitespace cleanup.
+func TestFlavorText(t *testing.T) {
+	var species pokemonSpecies
+	data := `{"flavor_text_entries": [
+		{"flavor_text": "When several of\nthese POKéMON\fgather...", "language": {"name": "en"}, "version": {"name": "red"}},
+		{"flavor_text": "Wenn mehrere...", "language": {"name": "de"}, "version": {"name": "x"}},
+		{"flavor_text": "It keeps its tail raised.", "language": {"name": "en"}, "version": {"name": "sword"}}
+	]}`
+	if err := json.Unmarshal([]byte(data), &species); err != nil {
+		t.Fatalf("could not parse species: %v", err)
+	}
+
+	if text, from := flavorText(species, "red"); text != "When several of these POKéMON gather..." || from != "red" {
+		t.Errorf("Unexpected red entry: %q from %v", text, from)
+	}
+	if text, from := flavorText(species, ""); text != "It keeps its tail raised." || from != "sword" {
+		t.Errorf("Expected the newest English entry, Got %q from %v", text, from)
+	}
+	if _, from := flavorText(species, "x"); from != "sword" {
+		t.Errorf("Expected an English fallback for a game without an English entry, Got %v", from)
+	}
+}